@@ -0,0 +1,261 @@
+package supervisord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+
+	"github.com/kolo/xmlrpc"
+)
+
+var (
+	ErrBatchNotExecuted = errors.New("batch not executed")
+	ErrBatchResponse    = errors.New("malformed multicall response")
+)
+
+// Batch queues calls and sends them to supervisord in a single system.multicall round trip.
+//
+// Example:
+//
+//	b := c.NewBatch()
+//	web := b.GetProcessInfo("web:web_00")
+//	stop := b.StopProcess("worker", true)
+//
+//	if err := b.Exec(); err != nil {
+//		return err
+//	}
+//
+//	info, err := web.Value()
+//	err = stop.Err()
+type Batch struct {
+	c *Client
+
+	calls   []CmdCall
+	entries []batchEntry
+}
+
+type batchEntry interface {
+	resolve(raw interface{})
+	fail(err error)
+}
+
+// BatchResult holds the typed outcome of one queued call, it is filled by Batch.Exec.
+type BatchResult[T any] struct {
+	value T
+	err   error
+}
+
+// Value returns the decoded result, or the fault supervisord returned for the call.
+func (r *BatchResult[T]) Value() (T, error) {
+	return r.value, r.err
+}
+
+// Err returns the error of the call, ErrBatchNotExecuted until the batch ran.
+func (r *BatchResult[T]) Err() error {
+	return r.err
+}
+
+func (r *BatchResult[T]) fail(err error) {
+	r.err = err
+}
+
+func (r *BatchResult[T]) resolve(raw interface{}) {
+	if f, ok := multicallFault(raw); ok {
+		r.err = f
+		return
+	}
+
+	arr, ok := raw.([]interface{})
+	if !ok || len(arr) != 1 {
+		r.err = fmt.Errorf("%w: unexpected entry %v", ErrBatchResponse, raw)
+		return
+	}
+
+	if r.err = recode(arr[0], &r.value); r.err != nil {
+		return
+	}
+
+	// a false bool reply is a failure, as in CallAsBool
+	if ok, isBool := any(r.value).(bool); isBool && !ok {
+		r.err = ErrorReturnedFalse
+	}
+}
+
+func (c *Client) NewBatch() *Batch {
+	return &Batch{c: c}
+}
+
+// BatchCall queues an arbitrary method on b, the reply is decoded into T.
+func BatchCall[T any](b *Batch, cmd CMD, args ...any) *BatchResult[T] {
+	params := make([]interface{}, 0, len(args))
+	params = append(params, args...)
+
+	r := &BatchResult[T]{err: ErrBatchNotExecuted}
+
+	b.calls = append(b.calls, CmdCall{MethodName: b.c.refineCmd(cmd), Params: params})
+	b.entries = append(b.entries, r)
+
+	return r
+}
+
+// Len returns the number of queued calls.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+func (b *Batch) Exec() error {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext sends all queued calls in one multicall and fills their results,
+// the returned error is only about the multicall itself, per call faults are kept in each result.
+func (b *Batch) ExecContext(ctx context.Context) error {
+	if len(b.calls) == 0 {
+		return nil
+	}
+
+	return b.fill(b.c.MulticallContext(ctx, b.calls))
+}
+
+// fill resolves the queued results from the multicall replies, or fails them all with err.
+func (b *Batch) fill(replies []interface{}, err error) error {
+	if err != nil {
+		for _, e := range b.entries {
+			e.fail(err)
+		}
+
+		return err
+	}
+
+	if len(replies) != len(b.entries) {
+		err = fmt.Errorf("%w: got %d results for %d calls", ErrBatchResponse, len(replies), len(b.entries))
+		for _, e := range b.entries {
+			e.fail(err)
+		}
+
+		return err
+	}
+
+	for i, e := range b.entries {
+		e.resolve(replies[i])
+	}
+
+	return nil
+}
+
+func (b *Batch) GetAPIVersion() *BatchResult[string] {
+	return BatchCall[string](b, getAPIVersion)
+}
+
+func (b *Batch) GetSupervisorVersion() *BatchResult[string] {
+	return BatchCall[string](b, getSupervisorVersion)
+}
+
+func (b *Batch) GetState() *BatchResult[State] {
+	return BatchCall[State](b, getState)
+}
+
+func (b *Batch) GetPID() *BatchResult[int] {
+	return BatchCall[int](b, getPID)
+}
+
+func (b *Batch) GetProcessInfo(name string) *BatchResult[ProcessInfo] {
+	return BatchCall[ProcessInfo](b, getProcessInfo, name)
+}
+
+func (b *Batch) GetAllProcessInfo() *BatchResult[[]ProcessInfo] {
+	return BatchCall[[]ProcessInfo](b, getAllProcessInfo)
+}
+
+func (b *Batch) GetAllConfigInfo() *BatchResult[[]ProcessConfig] {
+	return BatchCall[[]ProcessConfig](b, getAllConfigInfo)
+}
+
+func (b *Batch) StartProcess(name string, wait bool) *BatchResult[bool] {
+	return BatchCall[bool](b, startProcess, name, wait)
+}
+
+func (b *Batch) StopProcess(name string, wait bool) *BatchResult[bool] {
+	return BatchCall[bool](b, stopProcess, name, wait)
+}
+
+func (b *Batch) SignalProcess(name string, signal syscall.Signal) *BatchResult[bool] {
+	return BatchCall[bool](b, signalProcess, name, int(signal))
+}
+
+func (b *Batch) StartProcessGroup(name string, wait bool) *BatchResult[ProcessResults] {
+	return BatchCall[ProcessResults](b, startProcessGroup, name, wait)
+}
+
+func (b *Batch) StopProcessGroup(name string, wait bool) *BatchResult[ProcessResults] {
+	return BatchCall[ProcessResults](b, stopProcessGroup, name, wait)
+}
+
+func (b *Batch) SendProcessStdin(name string, chars string) *BatchResult[bool] {
+	return BatchCall[bool](b, sendProcessStdin, name, chars)
+}
+
+func (b *Batch) ReadProcessStdoutLog(name string, offset, length int) *BatchResult[string] {
+	return BatchCall[string](b, readProcessStdoutLog, name, offset, length)
+}
+
+func (b *Batch) ReadProcessStderrLog(name string, offset, length int) *BatchResult[string] {
+	return BatchCall[string](b, readProcessStderrLog, name, offset, length)
+}
+
+func (b *Batch) ClearProcessLogs(name string) *BatchResult[bool] {
+	return BatchCall[bool](b, clearProcessLogs, name)
+}
+
+// multicallFault reports whether a multicall entry is a {faultCode, faultString} struct.
+func multicallFault(raw interface{}) (*Fault, bool) {
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	code, ok := m["faultCode"].(int64)
+	if !ok {
+		return nil, false
+	}
+
+	str, _ := m["faultString"].(string)
+
+	return NewFault(int(code), str), true
+}
+
+// recode decodes a generically decoded xmlrpc value into dst, by encoding it back to xml.
+func recode(src interface{}, dst interface{}) error {
+	body, err := xmlrpc.EncodeMethodCall("recode", emptyStrings(src))
+	if err != nil {
+		return err
+	}
+
+	return xmlrpc.Response(body).Unmarshal(dst)
+}
+
+// emptyStrings restores the empty strings xmlrpc decodes as nil into interface{}, encoded back
+// as <value/> they would shift the following struct members.
+func emptyStrings(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = emptyStrings(e)
+		}
+
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			out[k] = emptyStrings(e)
+		}
+
+		return out
+	default:
+		return v
+	}
+}
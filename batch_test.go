@@ -0,0 +1,110 @@
+package supervisord
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const batchResp = `<?xml version="1.0"?><methodResponse><params><param><value><array><data>
+<value><array><data><value><struct>
+<member><name>name</name><value><string>web_00</string></value></member>
+<member><name>group</name><value><string>web</string></value></member>
+<member><name>spawnerr</name><value><string></string></value></member>
+<member><name>statename</name><value><string>RUNNING</string></value></member>
+<member><name>stdout_logfile</name><value><string></string></value></member>
+<member><name>state</name><value><int>20</int></value></member>
+<member><name>pid</name><value><int>42</int></value></member>
+</struct></value></data></array></value>
+<value><struct>
+<member><name>faultCode</name><value><int>60</int></value></member>
+<member><name>faultString</name><value><string>ALREADY_STARTED: worker</string></value></member>
+</struct></value>
+<value><array><data><value><boolean>0</boolean></value></data></array></value>
+<value><array><data><value><int>7</int></value></data></array></value>
+</data></array></value></param></params></methodResponse>`
+
+type BatchSuite struct {
+	suite.Suite
+	srv *httptest.Server
+}
+
+func TestBatch(t *testing.T) {
+	suite.Run(t, new(BatchSuite))
+}
+
+func (s *BatchSuite) SetupTest() {
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(batchResp))
+	}))
+}
+
+func (s *BatchSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *BatchSuite) Test_01_exec() {
+	c, err := NewClient(s.srv.URL+"/RPC2", WithCapabilityCheck(false))
+	s.Nil(err)
+
+	b := c.NewBatch()
+	info := b.GetProcessInfo("web:web_00")
+	start := b.StartProcess("worker", true)
+	stop := b.StopProcess("cron", true)
+	pid := b.GetPID()
+
+	s.ErrorIs(pid.Err(), ErrBatchNotExecuted)
+	s.Equal(4, b.Len())
+	s.Nil(b.Exec())
+
+	pi, err := info.Value()
+	s.Nil(err)
+	s.Equal("web:web_00", pi.FullName())
+	s.Equal(StateRunning, pi.State)
+	s.Equal(StateName("RUNNING"), pi.StateName)
+	s.Equal("", pi.SpawnErr)
+	s.Equal(42, pi.Pid)
+
+	s.ErrorIs(start.Err(), ErrAlreadyStarted)
+	s.ErrorIs(stop.Err(), ErrorReturnedFalse)
+
+	v, err := pid.Value()
+	s.Nil(err)
+	s.Equal(7, v)
+}
+
+func (s *BatchSuite) Test_02_mismatch() {
+	c, err := NewClient(s.srv.URL+"/RPC2", WithCapabilityCheck(false))
+	s.Nil(err)
+
+	b := c.NewBatch()
+	pid := b.GetPID()
+
+	s.ErrorIs(b.Exec(), ErrBatchResponse)
+	s.ErrorIs(pid.Err(), ErrBatchResponse)
+}
+
+func (s *BatchSuite) Test_03_multicallFault() {
+	f, ok := multicallFault(map[string]interface{}{"faultCode": int64(10), "faultString": "BAD_NAME: foo"})
+	s.True(ok)
+	s.Equal(FaultBadName, f.Code)
+	s.Equal("foo", f.Name)
+
+	_, ok = multicallFault(map[string]interface{}{"name": "foo"})
+	s.False(ok)
+
+	_, ok = multicallFault([]interface{}{true})
+	s.False(ok)
+}
+
+func (s *BatchSuite) Test_04_recodeEmptyStrings() {
+	for i := 0; i < 10; i++ {
+		raw := map[string]interface{}{"name": "web_00", "spawnerr": nil, "group": "web", "stdout_logfile": nil, "pid": int64(5)}
+
+		var pi ProcessInfo
+		s.Nil(recode(raw, &pi))
+		s.Equal(ProcessInfo{Name: "web_00", Group: "web", Pid: 5}, pi)
+	}
+}
@@ -0,0 +1,167 @@
+package supervisord
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"syscall"
+
+	"github.com/remeh/sizedwaitgroup"
+)
+
+const _defaultConcurrency = 8
+
+type bulkConfig struct {
+	concurrency int
+	failFast    bool
+}
+
+type BulkOptions func(*bulkConfig)
+
+// WithConcurrency limits how many calls of a bulk operation run at once, 8 by default.
+func WithConcurrency(n int) BulkOptions {
+	return func(o *bulkConfig) {
+		o.concurrency = n
+	}
+}
+
+// WithFailFast skips the calls not started yet once one call fails, they report context.Canceled.
+// Calls already running are left to finish.
+func WithFailFast(b bool) BulkOptions {
+	return func(o *bulkConfig) {
+		o.failFast = b
+	}
+}
+
+// BulkResult maps the "group:name" of every process of a bulk operation to its error, nil on success.
+type BulkResult map[string]error
+
+// Failed returns the sorted names of the processes whose call failed.
+func (r BulkResult) Failed() []string {
+	var names []string
+
+	for name, err := range r {
+		if err != nil {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Err joins the errors of the failed processes, nil when all succeeded.
+func (r BulkResult) Err() error {
+	var errs []error
+	for _, name := range r.Failed() {
+		errs = append(errs, r[name])
+	}
+
+	return errors.Join(errs...)
+}
+
+// Results converts r to ProcessResults sorted by name, errors which are not faults are reported as FAILED.
+func (r BulkResult) Results() ProcessResults {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	results := make(ProcessResults, 0, len(names))
+
+	for _, name := range names {
+		group, process := SplitName(name)
+		results = append(results, newProcessResult(group, process, r[name]))
+	}
+
+	return results
+}
+
+// Bulk calls fn for every process in names on a bounded pool of goroutines and collects
+// the error of each call. Processes not started when ctx is done report ctx.Err().
+// fn always gets ctx, fail fast only stops scheduling so no call is aborted half way.
+func (c *Client) Bulk(ctx context.Context, names []string, fn func(ctx context.Context, name string) error, opts ...BulkOptions) BulkResult {
+	cfg := &bulkConfig{concurrency: _defaultConcurrency}
+	for _, f := range opts {
+		f(cfg)
+	}
+
+	if cfg.concurrency < 1 {
+		cfg.concurrency = 1
+	}
+
+	// sched only gates starting new calls
+	sched, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+
+	res := make(BulkResult, len(names))
+	swg := sizedwaitgroup.New(cfg.concurrency)
+
+	for i, name := range names {
+		name = FullName(name)
+
+		err := swg.AddWithContext(sched)
+		if err == nil && sched.Err() != nil {
+			// a slot freed by the failing call may win over the cancellation
+			swg.Done()
+
+			err = sched.Err()
+		}
+
+		if err != nil {
+			mu.Lock()
+			for _, rest := range names[i:] {
+				res[FullName(rest)] = err
+			}
+			mu.Unlock()
+
+			break
+		}
+
+		go func() {
+			defer swg.Done()
+
+			err := fn(ctx, name)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			res[name] = err
+
+			if err != nil && cfg.failFast {
+				cancel()
+			}
+		}()
+	}
+
+	swg.Wait()
+
+	return res
+}
+
+// StartProcesses starts the processes concurrently.
+func (c *Client) StartProcesses(ctx context.Context, names []string, wait bool, opts ...BulkOptions) BulkResult {
+	return c.Bulk(ctx, names, func(ctx context.Context, name string) error {
+		return c.StartProcessContext(ctx, name, wait)
+	}, opts...)
+}
+
+// StopProcesses stops the processes concurrently.
+func (c *Client) StopProcesses(ctx context.Context, names []string, wait bool, opts ...BulkOptions) BulkResult {
+	return c.Bulk(ctx, names, func(ctx context.Context, name string) error {
+		return c.StopProcessContext(ctx, name, wait)
+	}, opts...)
+}
+
+// SignalProcesses sends signal to the processes concurrently.
+func (c *Client) SignalProcesses(ctx context.Context, names []string, signal syscall.Signal, opts ...BulkOptions) BulkResult {
+	return c.Bulk(ctx, names, func(ctx context.Context, name string) error {
+		return c.SignalProcessContext(ctx, name, signal)
+	}, opts...)
+}
@@ -0,0 +1,106 @@
+package supervisord
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BulkSuite struct {
+	suite.Suite
+	c *Client
+}
+
+func TestBulk(t *testing.T) {
+	suite.Run(t, new(BulkSuite))
+}
+
+func (s *BulkSuite) SetupTest() {
+	c, err := NewClient("http://127.0.0.1:9001/RPC2", WithCapabilityCheck(false))
+	s.Nil(err)
+
+	s.c = c
+}
+
+func (s *BulkSuite) Test_01_results() {
+	res := s.c.Bulk(context.Background(), []string{"web:web_00", "cron", "api"}, func(_ context.Context, name string) error {
+		if name == "cron:cron" {
+			return NewFault(int(FaultNotRunning), "NOT_RUNNING: cron:cron")
+		}
+
+		if name == "api:api" {
+			return errors.New("boom")
+		}
+
+		return nil
+	})
+
+	s.Len(res, 3)
+	s.Equal([]string{"api:api", "cron:cron"}, res.Failed())
+	s.ErrorIs(res.Err(), ErrNotRunning)
+
+	results := res.Results()
+	s.Equal(ProcessResult{Name: "api", Group: "api", Status: FaultFailed, Description: "FAILED: boom"}, results[0])
+	s.Equal(FaultNotRunning, results[1].Status)
+	s.Equal(FaultSuccess, results[2].Status)
+	s.Equal("web:web_00", results[2].FullName())
+}
+
+func (s *BulkSuite) Test_02_failFast() {
+	names := []string{"a", "b", "c"}
+
+	res := s.c.Bulk(context.Background(), names, func(ctx context.Context, name string) error {
+		switch name {
+		case "a:a":
+			return errors.New("boom")
+		case "b:b":
+			// still running when a fails, it must not be aborted
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(50 * time.Millisecond):
+				return nil
+			}
+		}
+
+		return nil
+	}, WithConcurrency(2), WithFailFast(true))
+
+	s.NotNil(res["a:a"])
+	s.Nil(res["b:b"])
+	s.ErrorIs(res["c:c"], context.Canceled)
+}
+
+func (s *BulkSuite) Test_03_cancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	res := s.c.Bulk(ctx, []string{"a", "b"}, func(context.Context, string) error {
+		calls++
+		return nil
+	})
+
+	s.Equal(0, calls)
+	s.ErrorIs(res["a:a"], context.Canceled)
+	s.ErrorIs(res["b:b"], context.Canceled)
+}
+
+func (s *BulkSuite) Test_04_failFastNoLateStart() {
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+	for i := 0; i < 200; i++ {
+		var started []string
+
+		res := s.c.Bulk(context.Background(), names, func(_ context.Context, name string) error {
+			started = append(started, name)
+			return errors.New("boom")
+		}, WithConcurrency(1), WithFailFast(true))
+
+		s.Equal([]string{"a:a"}, started)
+		s.ErrorIs(res["b:b"], context.Canceled)
+	}
+}
@@ -0,0 +1,152 @@
+package supervisord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var ErrUnsupportedMethod = errors.New("unsupported method")
+
+// Capabilities describes what the connected server implements, it is probed once per client.
+type Capabilities struct {
+	APIVersion        string
+	SupervisorVersion string
+
+	// Methods is the set returned by system.listMethods, nil when the server cannot list its methods.
+	Methods map[string]bool
+}
+
+// deprecatedAliases maps methods to the older names servers before supervisor 3.0 implement.
+var deprecatedAliases = map[CMD]CMD{
+	getAPIVersion:        getVersion,
+	readLog:              readMainLog,
+	readProcessStdoutLog: readProcessLog,
+	tailProcessStdoutLog: tailProcessLog,
+}
+
+// WithCapabilityCheck enables or disables probing the server before the first supervisor.*
+// or plugin call, enabled by default.
+func WithCapabilityCheck(b bool) ClientOptions {
+	return func(o *Client) {
+		o.capsCheck = b
+	}
+}
+
+// Supports reports whether the server implements method, e.g. "supervisor.getAllConfigInfo".
+// It is optimistic when the server cannot list its methods.
+func (cp *Capabilities) Supports(method string) bool {
+	return cp.Methods == nil || cp.Methods[method]
+}
+
+// AtLeast reports whether SupervisorVersion is version or newer, comparing dot separated numbers.
+func (cp *Capabilities) AtLeast(version string) bool {
+	have, want := strings.Split(cp.SupervisorVersion, "."), strings.Split(version, ".")
+
+	for i, w := range want {
+		wn, _ := strconv.Atoi(w)
+
+		hn := 0
+		if i < len(have) {
+			hn, _ = strconv.Atoi(have[i])
+		}
+
+		if hn != wn {
+			return hn > wn
+		}
+	}
+
+	return true
+}
+
+// Capabilities returns the cached capabilities of the server, probing it on first use.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	c.capsMu.Lock()
+	defer c.capsMu.Unlock()
+
+	if c.caps != nil {
+		return c.caps, nil
+	}
+
+	caps, err := c.probe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.caps = caps
+
+	return caps, nil
+}
+
+// probe fetches methods and versions in one multicall, bypassing the capability check and must.
+// Faults are not errors here, they only leave the matching fields empty.
+func (c *Client) probe(ctx context.Context) (*Capabilities, error) {
+	b := c.NewBatch()
+	methods := BatchCall[[]string](b, listMethods)
+	apiVersion := b.GetAPIVersion()
+	version := b.GetSupervisorVersion()
+	legacyVersion := BatchCall[string](b, getVersion)
+
+	var replies []interface{}
+
+	err := c.invoker(ctx, c.refineCmd(multicall), []interface{}{b.calls}, &replies)
+
+	var (
+		urlErr    *url.Error
+		statusErr *HTTPStatusError
+	)
+
+	if errors.As(err, &urlErr) || errors.As(err, &statusErr) {
+		return nil, err
+	}
+
+	if err != nil {
+		// system.multicall is missing or answers garbage, nothing can be told about the server.
+		return &Capabilities{}, nil //nolint:nilerr
+	}
+
+	_ = b.fill(replies, nil)
+
+	caps := &Capabilities{}
+	caps.APIVersion, err = apiVersion.Value()
+	if err != nil {
+		// servers before supervisor 3.0 only know the deprecated getVersion
+		caps.APIVersion, _ = legacyVersion.Value()
+	}
+
+	caps.SupervisorVersion, _ = version.Value()
+
+	if list, err := methods.Value(); err == nil {
+		caps.Methods = make(map[string]bool, len(list))
+		for _, m := range list {
+			caps.Methods[m] = true
+		}
+	}
+
+	return caps, nil
+}
+
+// negotiate returns the method to send for method, falling back to deprecated aliases,
+// or ErrUnsupportedMethod when the server lacks it.
+// When the server cannot be probed the method is sent as is, so the call reports the real error.
+func (c *Client) negotiate(ctx context.Context, method string) (string, error) {
+	if !c.capsCheck || strings.HasPrefix(method, _prefixSystem) {
+		return method, nil
+	}
+
+	caps, err := c.Capabilities(ctx)
+	if err != nil || caps.Supports(method) {
+		return method, nil
+	}
+
+	if alias, ok := deprecatedAliases[CMD(strings.TrimPrefix(method, _prefix))]; ok {
+		if aliased := c.refineCmd(alias); caps.Supports(aliased) {
+			return aliased, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s is not implemented by supervisor %s", ErrUnsupportedMethod, method, caps.SupervisorVersion)
+}
@@ -1,12 +1,15 @@
 package supervisord
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/kolo/xmlrpc"
-	"github.com/rs/zerolog/log"
 )
 
 type Client struct {
@@ -15,9 +18,32 @@ type Client struct {
 	host     string
 	username string
 	password string
+	socket   string
 
-	must  bool
-	debug bool
+	rpcURL     string
+	httpClient *http.Client
+	transport  transportConfig
+	retry      *RetryPolicy
+
+	must   bool
+	debug  bool
+	logger Logger
+	trace  TraceFunc
+
+	interceptors []Interceptor
+	invoker      Invoker
+
+	capsCheck bool
+	capsMu    sync.Mutex
+	caps      *Capabilities
+
+	nsMu       sync.RWMutex
+	namespaces map[string]bool
+
+	pollEvery time.Duration
+
+	// optErr collects failures of options which load files, NewClient returns it.
+	optErr error
 }
 
 var ErrorReturnedFalse = errors.New("Call returned false")
@@ -43,25 +69,61 @@ func WithMust(b bool) ClientOptions {
 	}
 }
 
+// WithUnixSocket makes the client dial supervisord through the unix socket at path,
+// the url passed to NewClient is then only used for display.
+func WithUnixSocket(path string) ClientOptions {
+	return func(o *Client) {
+		o.socket = path
+	}
+}
+
+// NewClient creates a client for the supervisord xmlrpc interface at url,
+// both http://host:port/RPC2 and unix:///path/to/supervisor.sock are accepted.
 func NewClient(url string, opts ...ClientOptions) (*Client, error) {
-	opt := &Client{}
-	bindOptions(opt, opts...)
+	c := &Client{capsCheck: true}
+	bindOptions(c, opts...)
 
-	tr := newBasicAuth(opt.username, opt.password)
+	if c.optErr != nil {
+		return nil, c.optErr
+	}
+
+	rpcURL, socket := resolveURL(url, c.socket)
 
-	rpc, err := xmlrpc.NewClient(url, tr)
+	rt, err := c.transport.roundTripper(socket)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{
-		Client:   rpc,
-		host:     url,
-		username: opt.username,
-		password: opt.password,
-		must:     opt.must,
-		debug:    true,
-	}, nil
+	tr := newBasicAuth(c.username, c.password)
+	tr.rt = rt
+
+	rpc, err := xmlrpc.NewClient(rpcURL, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	hc, err := c.transport.httpClient(tr)
+	if err != nil {
+		return nil, err
+	}
+
+	if url == "" {
+		url = _schemeUnix + socket
+	}
+
+	c.Client = rpc
+	c.host = url
+	c.socket = socket
+	c.rpcURL = rpcURL
+	c.httpClient = hc
+
+	if c.logger == nil {
+		c.logger = zerologLogger{}
+	}
+
+	c.invoker = chainInterceptors(c.invokeWithTrace, c.interceptors)
+
+	return c, nil
 }
 
 func (c *Client) String() string {
@@ -69,53 +131,85 @@ func (c *Client) String() string {
 }
 
 func (c *Client) CallAsStr(serviceMethod CMD, args ...any) (string, error) {
+	return c.CallAsStrContext(context.Background(), serviceMethod, args...)
+}
+
+func (c *Client) CallAsStrContext(ctx context.Context, serviceMethod CMD, args ...any) (string, error) {
 	var reply string
-	err := c.call(serviceMethod, c.refineArgs(args...), &reply)
+	err := c.callContext(ctx, serviceMethod, c.refineArgs(args...), &reply)
 
 	return reply, err
 }
 
 func (c *Client) CallAsStrArray(serviceMethod CMD, args ...any) ([]string, error) {
+	return c.CallAsStrArrayContext(context.Background(), serviceMethod, args...)
+}
+
+func (c *Client) CallAsStrArrayContext(ctx context.Context, serviceMethod CMD, args ...any) ([]string, error) {
 	var reply []string
-	err := c.call(serviceMethod, nil, &reply)
+	err := c.callContext(ctx, serviceMethod, nil, &reply)
 
 	return reply, err
 }
 
 func (c *Client) CallAsInt(serviceMethod CMD, args ...any) (int, error) {
+	return c.CallAsIntContext(context.Background(), serviceMethod, args...)
+}
+
+func (c *Client) CallAsIntContext(ctx context.Context, serviceMethod CMD, args ...any) (int, error) {
 	var reply int
-	err := c.call(serviceMethod, c.refineArgs(args...), &reply)
+	err := c.callContext(ctx, serviceMethod, c.refineArgs(args...), &reply)
 
 	return reply, err
 }
 
 func (c *Client) CallAsBool(serviceMethod CMD, args ...any) error {
+	return c.CallAsBoolContext(context.Background(), serviceMethod, args...)
+}
+
+func (c *Client) CallAsBoolContext(ctx context.Context, serviceMethod CMD, args ...any) error {
 	var reply bool
-	err := c.call(serviceMethod, c.refineArgs(args...), &reply)
+	if err := c.callContext(ctx, serviceMethod, c.refineArgs(args...), &reply); err != nil {
+		return err
+	}
 
 	if !reply {
 		return ErrorReturnedFalse
 	}
 
-	return err
+	return nil
 }
 
 func (c *Client) CallAsInterface(cmdIn CMD, args ...any) (interface{}, error) {
+	return c.CallAsInterfaceContext(context.Background(), cmdIn, args...)
+}
+
+func (c *Client) CallAsInterfaceContext(ctx context.Context, cmdIn CMD, args ...any) (interface{}, error) {
 	var reply interface{}
-	err := c.call(cmdIn, args, &reply)
+	err := c.callContext(ctx, cmdIn, args, &reply)
 
 	return reply, err
 }
 
 func (c *Client) CallAsInterfaceArray(cmdIn CMD, args ...any) ([]interface{}, error) {
+	return c.CallAsInterfaceArrayContext(context.Background(), cmdIn, args...)
+}
+
+func (c *Client) CallAsInterfaceArrayContext(ctx context.Context, cmdIn CMD, args ...any) ([]interface{}, error) {
 	var arr []interface{}
-	err := c.call(cmdIn, args, &arr)
+	err := c.callContext(ctx, cmdIn, args, &arr)
 
 	return arr, err
 }
 
-func (c *Client) call(serviceMethod CMD, args any, reply any) error {
-	err := c.Call(c.refineCmd(serviceMethod), args, reply)
+func (c *Client) callContext(ctx context.Context, serviceMethod CMD, args any, reply any) error {
+	method, err := c.negotiate(ctx, c.refineCmd(serviceMethod))
+	if err != nil {
+		return c.pie(err)
+	}
+
+	err = c.invoker(ctx, method, args, reply)
+
 	return c.pie(err)
 }
 
@@ -136,24 +230,16 @@ func (c *Client) refineArgs(args ...any) any {
 		return nil
 	}
 
-	if c.debug {
-		log.Debug().Interface("args", args).Msg("got args")
-	}
-
 	return args
 }
 
 func (c *Client) refineCmd(rawCmd CMD) string {
 	cmd := string(rawCmd)
-	if strings.HasPrefix(cmd, _prefixSystem) {
+	if c.hasNamespace(cmd) {
 		return cmd
 	}
 
-	if !strings.HasPrefix(cmd, _prefix) {
-		cmd = _prefix + cmd
-	}
-
-	return cmd
+	return _prefix + cmd
 }
 
 func genCmd(cmdIn CMD, _typ string) {
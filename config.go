@@ -0,0 +1,229 @@
+package supervisord
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const _defaultServerURL = "http://localhost:9001"
+
+var ErrConfigNotFound = errors.New("supervisord.conf not found")
+
+// configSearchPaths returns the paths supervisorctl looks for its config in when -c is not given,
+// the first two are relative to the parent of the directory holding the executable.
+func configSearchPaths(exe string) []string {
+	here := filepath.Dir(filepath.Dir(exe))
+
+	return []string{
+		filepath.Join(here, "etc", "supervisord.conf"),
+		filepath.Join(here, "supervisord.conf"),
+		"supervisord.conf",
+		"etc/supervisord.conf",
+		"/etc/supervisord.conf",
+		"/etc/supervisor/supervisord.conf",
+	}
+}
+
+// CtlConfig is the [supervisorctl] section of supervisord.conf.
+type CtlConfig struct {
+	ServerURL string
+	Username  string
+	Password  string
+}
+
+// NewClientFromConfig creates a client the way supervisorctl does, using serverurl, username
+// and password of the [supervisorctl] section in the config at path, or in the first config
+// found on the supervisorctl search path when path is empty.
+// opts are applied after the ones built from the config, so they can override them.
+func NewClientFromConfig(path string, opts ...ClientOptions) (*Client, error) {
+	cfg, err := ReadCtlConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	all := []ClientOptions{WithAuth(cfg.Username, cfg.Password)}
+	all = append(all, opts...)
+
+	return NewClient(cfg.ServerURL, all...)
+}
+
+// FindConfig returns the first existing config on the supervisorctl search path.
+func FindConfig() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("cannot find config: %w", err)
+	}
+
+	for _, p := range configSearchPaths(exe) {
+		if _, err := os.Stat(p); err == nil {
+			return filepath.Abs(p)
+		}
+	}
+
+	return "", ErrConfigNotFound
+}
+
+// ReadCtlConfig reads the [supervisorctl] section of the config at path, following [include] files.
+func ReadCtlConfig(path string) (*CtlConfig, error) {
+	if path == "" {
+		found, err := FindConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		path = found
+	}
+
+	// %(here)s is the absolute directory of the config in supervisor
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read config: %w", err)
+	}
+
+	sections := make(iniSections)
+	if err := sections.load(path, 0); err != nil {
+		return nil, err
+	}
+
+	ctl := sections["supervisorctl"]
+
+	cfg := &CtlConfig{
+		ServerURL: ctl["serverurl"],
+		Username:  ctl["username"],
+		Password:  ctl["password"],
+	}
+
+	if cfg.ServerURL == "" {
+		cfg.ServerURL = _defaultServerURL
+	}
+
+	return cfg, nil
+}
+
+// iniSections maps section names to their expanded key/value pairs.
+type iniSections map[string]map[string]string
+
+// includes deeper than this are considered recursive.
+const _maxIncludeDepth = 10
+
+var expansionRx = regexp.MustCompile(`%\(([^)]+)\)s`)
+
+// load parses the ini file at path into s, values of later files override earlier ones
+// like in supervisor, where included files are read after the main config.
+func (s iniSections) load(path string, depth int) error {
+	if depth > _maxIncludeDepth {
+		return fmt.Errorf("cannot read %s: includes nested too deep", path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("cannot read config: %w", err)
+	}
+	defer f.Close()
+
+	here := filepath.Dir(path)
+	section, key := "", ""
+
+	own := make(iniSections)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#"):
+			continue
+		case strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]"):
+			section, key = strings.TrimSpace(trimmed[1:len(trimmed)-1]), ""
+			if own[section] == nil {
+				own[section] = make(map[string]string)
+			}
+		case line[0] == ' ' || line[0] == '\t':
+			// continuation of a multi-line value
+			if section != "" && key != "" {
+				own[section][key] += "\n" + stripInlineComment(trimmed)
+			}
+		case section != "":
+			k, v, found := strings.Cut(trimmed, "=")
+			if !found {
+				k, v, found = strings.Cut(trimmed, ":")
+			}
+
+			if !found {
+				return fmt.Errorf("cannot parse %s: invalid line %q", path, line)
+			}
+
+			key = strings.ToLower(strings.TrimSpace(k))
+			own[section][key] = stripInlineComment(strings.TrimSpace(v))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("cannot read config: %w", err)
+	}
+
+	for name, values := range own {
+		if s[name] == nil {
+			s[name] = make(map[string]string)
+		}
+
+		for k, v := range values {
+			s[name][k] = expandValue(v, here)
+		}
+	}
+
+	files := own["include"]["files"]
+
+	for _, pattern := range strings.Fields(expandValue(files, here)) {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(here, pattern)
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("cannot expand include %s: %w", pattern, err)
+		}
+
+		for _, m := range matches {
+			if err := s.load(m, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// stripInlineComment removes a " ;" comment from the end of a value.
+func stripInlineComment(v string) string {
+	if i := strings.Index(v, " ;"); i >= 0 {
+		return strings.TrimSpace(v[:i])
+	}
+
+	return v
+}
+
+// expandValue replaces %(here)s, %(host_node_name)s and %(ENV_X)s like supervisor does.
+func expandValue(v string, here string) string {
+	return expansionRx.ReplaceAllStringFunc(v, func(m string) string {
+		name := expansionRx.FindStringSubmatch(m)[1]
+
+		switch {
+		case name == "here":
+			return here
+		case name == "host_node_name":
+			host, _ := os.Hostname()
+			return host
+		case strings.HasPrefix(name, "ENV_"):
+			return os.Getenv(strings.TrimPrefix(name, "ENV_"))
+		default:
+			return m
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package supervisord
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+	dir string
+}
+
+func TestConfig(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
+}
+
+func (s *ConfigSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+}
+
+func (s *ConfigSuite) write(name, content string) string {
+	p := filepath.Join(s.dir, name)
+	s.Nil(os.MkdirAll(filepath.Dir(p), 0o755))
+	s.Nil(os.WriteFile(p, []byte(content), 0o600))
+
+	return p
+}
+
+func (s *ConfigSuite) Test_01_defaults() {
+	p := s.write("supervisord.conf", "[supervisord]\nlogfile=/tmp/x.log\n")
+
+	cfg, err := ReadCtlConfig(p)
+	s.Nil(err)
+	s.Equal(_defaultServerURL, cfg.ServerURL)
+	s.Equal("", cfg.Username)
+}
+
+func (s *ConfigSuite) Test_02_includeAndHere() {
+	s.T().Setenv("SUPERVISOR_PWD", "123")
+
+	p := s.write("supervisord.conf", `; main config
+[supervisorctl]
+serverurl = unix://%(here)s/supervisor.sock ; inline comment
+username = user
+
+[include]
+files = conf.d/*.conf
+`)
+	s.write("conf.d/ctl.conf", "[supervisorctl]\npassword = %(ENV_SUPERVISOR_PWD)s\n")
+
+	cfg, err := ReadCtlConfig(p)
+	s.Nil(err)
+	s.Equal("unix://"+s.dir+"/supervisor.sock", cfg.ServerURL)
+	s.Equal("user", cfg.Username)
+	s.Equal("123", cfg.Password)
+
+	c, err := NewClientFromConfig(p)
+	s.Nil(err)
+	s.Equal(s.dir+"/supervisor.sock", c.socket)
+}
+
+func (s *ConfigSuite) Test_03_relativeHere() {
+	s.write("supervisord.conf", "[supervisorctl]\nserverurl = unix://%(here)s/supervisor.sock\n")
+
+	wd, err := os.Getwd()
+	s.Nil(err)
+	s.Nil(os.Chdir(s.dir))
+
+	defer os.Chdir(wd) //nolint:errcheck
+
+	cfg, err := ReadCtlConfig("supervisord.conf")
+	s.Nil(err)
+
+	dir, err := filepath.EvalSymlinks(s.dir)
+	s.Nil(err)
+
+	here := strings.TrimSuffix(strings.TrimPrefix(cfg.ServerURL, "unix://"), "/supervisor.sock")
+	here, err = filepath.EvalSymlinks(here)
+	s.Nil(err)
+	s.Equal(dir, here)
+}
+
+func (s *ConfigSuite) Test_04_searchPaths() {
+	paths := configSearchPaths("/opt/supervisor/bin/supervisorctl")
+	s.Equal([]string{
+		"/opt/supervisor/etc/supervisord.conf",
+		"/opt/supervisor/supervisord.conf",
+		"supervisord.conf",
+		"etc/supervisord.conf",
+		"/etc/supervisord.conf",
+		"/etc/supervisor/supervisord.conf",
+	}, paths)
+}
+
+func (s *ConfigSuite) Test_05_findConfig() {
+	s.write("etc/supervisord.conf", "[supervisorctl]\n")
+
+	wd, err := os.Getwd()
+	s.Nil(err)
+	s.Nil(os.Chdir(s.dir))
+
+	defer os.Chdir(wd) //nolint:errcheck
+
+	// a ../etc/supervisord.conf of the working directory is not on the search path
+	s.Nil(os.Mkdir(filepath.Join(s.dir, "sub"), 0o755))
+	s.Nil(os.Chdir(filepath.Join(s.dir, "sub")))
+
+	exe, err := os.Executable()
+	s.Nil(err)
+
+	for _, p := range configSearchPaths(exe) {
+		if _, err := os.Stat(p); err == nil && filepath.IsAbs(p) {
+			s.T().Skip("a config exists at " + p)
+		}
+	}
+
+	_, err = FindConfig()
+	s.ErrorIs(err, ErrConfigNotFound)
+
+	s.Nil(os.Chdir(s.dir))
+
+	found, err := FindConfig()
+	s.Nil(err)
+
+	dir, err := filepath.EvalSymlinks(s.dir)
+	s.Nil(err)
+
+	found, err = filepath.EvalSymlinks(found)
+	s.Nil(err)
+	s.Equal(filepath.Join(dir, "etc", "supervisord.conf"), found)
+}
@@ -0,0 +1,391 @@
+package supervisord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/kolo/xmlrpc"
+)
+
+var (
+	methodNameRx = regexp.MustCompile(`<methodName>([^<]+)</methodName>`)
+	paramsRx     = regexp.MustCompile(`(?s)<params>(.*)</params>`)
+)
+
+// fakeProcess is a program of fakeSupervisor.
+type fakeProcess struct {
+	info   ProcessInfo
+	config ProcessConfig
+
+	failStart  bool           // startProcess leaves it FATAL
+	startDelay time.Duration  // time spent STARTING before RUNNING
+	ignoreStop bool           // ignores its stopsignal, stopProcess then leaves it STOPPING until supervisord kills it after stopWait
+	exitOn     syscall.Signal // signal it exits on by itself, like a drain signal
+	stdout     string         // content of the stdout log
+}
+
+func newFakeProcess(group, name string, state ProcessState) *fakeProcess {
+	return &fakeProcess{
+		info:   ProcessInfo{Group: group, Name: name, State: state},
+		config: ProcessConfig{Group: group, Name: name, Exitcodes: []int{0}},
+	}
+}
+
+func (p *fakeProcess) stopSignal() syscall.Signal {
+	if p.config.Stopsignal == 0 {
+		return syscall.SIGTERM
+	}
+
+	return syscall.Signal(p.config.Stopsignal)
+}
+
+// fakeSupervisor is an in-memory supervisord answering the xmlrpc calls of the client,
+// processes change state instantly unless told otherwise.
+type fakeSupervisor struct {
+	*httptest.Server
+	t *testing.T
+
+	mu       sync.Mutex
+	pid      int
+	nextPID  int
+	procs    []*fakeProcess
+	calls    []string
+	signals  []string      // "group:name signal" of every accepted signalProcess
+	down     bool          // answer 503 to every request
+	stopWait time.Duration // how long supervisord lets a process ignore its stopsignal before SIGKILL
+}
+
+func newFakeSupervisor(t *testing.T, procs ...*fakeProcess) *fakeSupervisor {
+	f := &fakeSupervisor{t: t, pid: 1, nextPID: 100, procs: procs, stopWait: time.Second}
+
+	for _, p := range procs {
+		if p.info.State == StateRunning {
+			f.nextPID++
+			p.info.Pid = f.nextPID
+			p.info.Start = int(time.Now().Unix())
+		}
+	}
+
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func (f *fakeSupervisor) client(opts ...ClientOptions) *Client {
+	opts = append([]ClientOptions{WithCapabilityCheck(false), WithPollInterval(5 * time.Millisecond)}, opts...)
+
+	c, err := NewClient(f.URL+"/RPC2", opts...)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+
+	return c
+}
+
+// info returns a copy of the process info of name.
+func (f *fakeSupervisor) info(name string) ProcessInfo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.find(name).info
+}
+
+// called returns the calls made for method, e.g. "supervisor.startProcess web:web_00".
+func (f *fakeSupervisor) called(method string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var calls []string
+
+	for _, call := range f.calls {
+		if strings.HasPrefix(call, method+" ") || call == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+func (f *fakeSupervisor) set(fn func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	fn()
+}
+
+func (f *fakeSupervisor) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	method := methodNameRx.FindSubmatch(body)[1]
+
+	var args []any
+	if m := paramsRx.FindSubmatch(body); m != nil {
+		values := strings.NewReplacer("<param>", "", "</param>", "").Replace(string(m[1]))
+		if err := xmlrpc.Response("<value><array><data>" + values + "</data></array></value>").Unmarshal(&args); err != nil {
+			f.t.Error(err)
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	reply, err := f.dispatch(r.Context(), string(method), args)
+	if err != nil {
+		fault := faultOf(err)
+		fmt.Fprintf(w, `<?xml version="1.0"?><methodResponse><fault><value><struct>`+
+			`<member><name>faultCode</name><value><int>%d</int></value></member>`+
+			`<member><name>faultString</name><value><string>%s</string></value></member>`+
+			`</struct></value></fault></methodResponse>`, fault["faultCode"], fault["faultString"])
+
+		return
+	}
+
+	call, err := xmlrpc.EncodeMethodCall("reply", reply)
+	if err != nil {
+		f.t.Error(err)
+	}
+
+	_, _ = fmt.Fprintf(w, `<?xml version="1.0"?><methodResponse>%s</methodResponse>`, paramsRx.Find(call))
+}
+
+// dispatch answers one call, it runs with f.mu held.
+func (f *fakeSupervisor) dispatch(ctx context.Context, method string, args []any) (any, error) {
+	call := method
+	if len(args) > 0 {
+		if name, ok := args[0].(string); ok {
+			call += " " + name
+		}
+	}
+
+	f.calls = append(f.calls, call)
+
+	switch method {
+	case "system.multicall":
+		var replies []any
+
+		for _, raw := range args[0].([]any) {
+			sub := raw.(map[string]any)
+			params, _ := sub["params"].([]any)
+
+			reply, err := f.dispatch(ctx, sub["methodName"].(string), params)
+			if err != nil {
+				replies = append(replies, faultOf(err))
+			} else {
+				replies = append(replies, []any{reply})
+			}
+		}
+
+		return replies, nil
+	case "supervisor.getPID":
+		return f.pid, nil
+	case "supervisor.getAllProcessInfo":
+		infos := make([]ProcessInfo, 0, len(f.procs))
+		for _, p := range f.procs {
+			infos = append(infos, f.now(p))
+		}
+
+		return infos, nil
+	case "supervisor.getAllConfigInfo":
+		configs := make([]ProcessConfig, 0, len(f.procs))
+		for _, p := range f.procs {
+			configs = append(configs, p.config)
+		}
+
+		return configs, nil
+	case "supervisor.startProcessGroup", "supervisor.stopProcessGroup":
+		return f.group(ctx, method, args[0].(string), args[1].(bool))
+	}
+
+	if len(args) == 0 {
+		return nil, &Fault{Code: FaultUnknownMethod}
+	}
+
+	p := f.find(args[0].(string))
+	if p == nil {
+		return nil, &Fault{Code: FaultBadName, Description: args[0].(string)}
+	}
+
+	switch method {
+	case "supervisor.getProcessInfo":
+		return f.now(p), nil
+	case "supervisor.startProcess":
+		return true, f.start(p, args[1].(bool))
+	case "supervisor.stopProcess":
+		return true, f.stop(ctx, p)
+	case "supervisor.signalProcess":
+		return true, f.signal(p, syscall.Signal(args[1].(int64)))
+	case "supervisor.readProcessStdoutLog":
+		offset, length := min(int(args[1].(int64)), len(p.stdout)), int(args[2].(int64))
+		return p.stdout[offset:min(offset+length, len(p.stdout))], nil
+	}
+
+	return nil, &Fault{Code: FaultUnknownMethod}
+}
+
+func (f *fakeSupervisor) find(name string) *fakeProcess {
+	name = FullName(name)
+
+	for _, p := range f.procs {
+		if p.info.FullName() == name {
+			return p
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSupervisor) now(p *fakeProcess) ProcessInfo {
+	info := p.info
+	info.Now = int(time.Now().Unix())
+	info.StateName = StateName(info.State.String())
+
+	return info
+}
+
+func (f *fakeSupervisor) group(ctx context.Context, method, group string, wait bool) (ProcessResults, error) {
+	var results ProcessResults
+
+	for _, p := range f.procs {
+		if p.info.Group != group {
+			continue
+		}
+
+		var err error
+		if method == "supervisor.startProcessGroup" {
+			err = f.start(p, wait)
+		} else {
+			err = f.stop(ctx, p)
+		}
+
+		results = append(results, newProcessResult(p.info.Group, p.info.Name, err))
+	}
+
+	if results == nil {
+		return nil, &Fault{Code: FaultBadName, Description: group}
+	}
+
+	return results, nil
+}
+
+var fakeAliveStates = []ProcessState{StateRunning, StateStarting, StateBackoff}
+
+func (f *fakeSupervisor) start(p *fakeProcess, wait bool) error {
+	if slices.Contains(fakeAliveStates, p.info.State) {
+		return &Fault{Code: FaultAlreadyStarted, Description: p.info.FullName()}
+	}
+
+	f.nextPID++
+	p.info.Pid, p.info.Start, p.info.SpawnErr = f.nextPID, int(time.Now().Unix()), ""
+
+	switch {
+	case p.failStart:
+		p.info.State, p.info.Pid, p.info.SpawnErr = StateFatal, 0, "Exited too quickly (process log may have details)"
+
+		if wait {
+			return &Fault{Code: FaultSpawnError, Description: p.info.FullName()}
+		}
+	case p.startDelay > 0:
+		p.info.State = StateStarting
+
+		time.AfterFunc(p.startDelay, func() {
+			f.set(func() {
+				if p.info.State == StateStarting {
+					p.info.State = StateRunning
+				}
+			})
+		})
+	default:
+		p.info.State = StateRunning
+	}
+
+	return nil
+}
+
+// stop stops p, a process ignoring its stopsignal keeps the call blocked in STOPPING
+// until supervisord kills it after stopWait.
+func (f *fakeSupervisor) stop(ctx context.Context, p *fakeProcess) error {
+	if !slices.Contains(fakeAliveStates, p.info.State) {
+		return &Fault{Code: FaultNotRunning, Description: p.info.FullName()}
+	}
+
+	if !p.ignoreStop {
+		f.exit(p, StateStopped, 0)
+		return nil
+	}
+
+	p.info.State = StateStopping
+
+	time.AfterFunc(f.stopWait, func() {
+		f.set(func() {
+			if p.info.State == StateStopping {
+				f.exit(p, StateStopped, -9)
+			}
+		})
+	})
+
+	for p.info.State == StateStopping && ctx.Err() == nil {
+		f.mu.Unlock()
+		time.Sleep(time.Millisecond)
+		f.mu.Lock()
+	}
+
+	return nil
+}
+
+// signal delivers sig like supervisord, which only signals RUNNING, STARTING and BACKOFF processes.
+func (f *fakeSupervisor) signal(p *fakeProcess, sig syscall.Signal) error {
+	if !slices.Contains(fakeAliveStates, p.info.State) {
+		return &Fault{Code: FaultNotRunning, Description: p.info.FullName()}
+	}
+
+	f.signals = append(f.signals, fmt.Sprintf("%s %s", p.info.FullName(), sig))
+
+	switch {
+	case sig == syscall.SIGKILL:
+		f.exit(p, StateExited, -9)
+	case sig == p.exitOn:
+		f.exit(p, StateExited, 0)
+	case sig == p.stopSignal() && !p.ignoreStop:
+		f.exit(p, StateExited, -int(sig))
+	}
+
+	return nil
+}
+
+func (f *fakeSupervisor) exit(p *fakeProcess, state ProcessState, status int) {
+	p.info.State, p.info.Pid, p.info.ExitStatus, p.info.Stop = state, 0, status, int(time.Now().Unix())
+}
+
+// faultOf encodes err as the {faultCode, faultString} struct of xmlrpc.
+func faultOf(err error) map[string]any {
+	code, ok := FaultCodeOf(err)
+	if !ok {
+		code = FaultFailed
+	}
+
+	str := code.String()
+
+	var f *Fault
+	if errors.As(err, &f) {
+		str = f.faultString()
+	}
+
+	return map[string]any{"faultCode": int(code), "faultString": str}
+}
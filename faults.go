@@ -0,0 +1,172 @@
+package supervisord
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kolo/xmlrpc"
+)
+
+// FaultCode is the faultCode supervisord returns in xmlrpc faults.
+//
+// http://supervisord.org/api.html#supervisor.rpcinterface.SupervisorNamespaceRPCInterface
+type FaultCode int
+
+const (
+	FaultUnknownMethod        FaultCode = 1
+	FaultIncorrectParameters  FaultCode = 2
+	FaultBadArguments         FaultCode = 3
+	FaultSignatureUnsupported FaultCode = 4
+	FaultShutdownState        FaultCode = 6
+	FaultBadName              FaultCode = 10
+	FaultBadSignal            FaultCode = 11
+	FaultNoFile               FaultCode = 20
+	FaultNotExecutable        FaultCode = 21
+	FaultFailed               FaultCode = 30
+	FaultAbnormalTermination  FaultCode = 40
+	FaultSpawnError           FaultCode = 50
+	FaultAlreadyStarted       FaultCode = 60
+	FaultNotRunning           FaultCode = 70
+	FaultSuccess              FaultCode = 80
+	FaultAlreadyAdded         FaultCode = 90
+	FaultStillRunning         FaultCode = 91
+	FaultCantReread           FaultCode = 92
+)
+
+var (
+	ErrUnknownMethod        = errors.New("UNKNOWN_METHOD")
+	ErrIncorrectParameters  = errors.New("INCORRECT_PARAMETERS")
+	ErrBadArguments         = errors.New("BAD_ARGUMENTS")
+	ErrSignatureUnsupported = errors.New("SIGNATURE_UNSUPPORTED")
+	ErrShutdownState        = errors.New("SHUTDOWN_STATE")
+	ErrBadName              = errors.New("BAD_NAME")
+	ErrBadSignal            = errors.New("BAD_SIGNAL")
+	ErrNoFile               = errors.New("NO_FILE")
+	ErrNotExecutable        = errors.New("NOT_EXECUTABLE")
+	ErrFailed               = errors.New("FAILED")
+	ErrAbnormalTermination  = errors.New("ABNORMAL_TERMINATION")
+	ErrSpawnError           = errors.New("SPAWN_ERROR")
+	ErrAlreadyStarted       = errors.New("ALREADY_STARTED")
+	ErrNotRunning           = errors.New("NOT_RUNNING")
+	ErrAlreadyAdded         = errors.New("ALREADY_ADDED")
+	ErrStillRunning         = errors.New("STILL_RUNNING")
+	ErrCantReread           = errors.New("CANT_REREAD")
+)
+
+var faultErrors = map[FaultCode]error{
+	FaultUnknownMethod:        ErrUnknownMethod,
+	FaultIncorrectParameters:  ErrIncorrectParameters,
+	FaultBadArguments:         ErrBadArguments,
+	FaultSignatureUnsupported: ErrSignatureUnsupported,
+	FaultShutdownState:        ErrShutdownState,
+	FaultBadName:              ErrBadName,
+	FaultBadSignal:            ErrBadSignal,
+	FaultNoFile:               ErrNoFile,
+	FaultNotExecutable:        ErrNotExecutable,
+	FaultFailed:               ErrFailed,
+	FaultAbnormalTermination:  ErrAbnormalTermination,
+	FaultSpawnError:           ErrSpawnError,
+	FaultAlreadyStarted:       ErrAlreadyStarted,
+	FaultNotRunning:           ErrNotRunning,
+	FaultAlreadyAdded:         ErrAlreadyAdded,
+	FaultStillRunning:         ErrStillRunning,
+	FaultCantReread:           ErrCantReread,
+}
+
+// faults whose description is the name of the process or group the call was made for.
+var namedFaults = map[FaultCode]bool{
+	FaultBadName:             true,
+	FaultSpawnError:          true,
+	FaultAbnormalTermination: true,
+	FaultAlreadyStarted:      true,
+	FaultNotRunning:          true,
+	FaultAlreadyAdded:        true,
+	FaultStillRunning:        true,
+}
+
+func (f FaultCode) String() string {
+	if f == FaultSuccess {
+		return "SUCCESS"
+	}
+
+	if err, ok := faultErrors[f]; ok {
+		return err.Error()
+	}
+
+	return fmt.Sprintf("FAULT_%d", int(f))
+}
+
+// Fault is a supervisord xmlrpc fault, use errors.Is with the Err* sentinels to check its code.
+type Fault struct {
+	Code        FaultCode
+	Name        string // process or group name the fault refers to, empty when it does not name one
+	Description string // text following the fault name in faultString
+}
+
+// NewFault parses the faultString supervisord sends ("BAD_NAME: foo") into a Fault.
+func NewFault(code int, faultString string) *Fault {
+	f := &Fault{Code: FaultCode(code)}
+
+	prefix := f.Code.String()
+	desc := strings.TrimPrefix(faultString, prefix)
+
+	if desc == faultString {
+		f.Description = faultString
+	} else {
+		f.Description = strings.TrimSpace(strings.TrimPrefix(desc, ":"))
+	}
+
+	if namedFaults[f.Code] {
+		f.Name = f.Description
+	}
+
+	return f
+}
+
+func (f *Fault) Error() string {
+	parts := []string{f.Code.String()}
+
+	if f.Name != "" {
+		parts = append(parts, f.Name)
+	}
+
+	if f.Description != "" && f.Description != f.Name && f.Description != f.Code.String() {
+		parts = append(parts, f.Description)
+	}
+
+	return fmt.Sprintf("Fault(%d): %s", f.Code, strings.Join(parts, ": "))
+}
+
+// faultString returns the "CODE: description" text supervisord sends for the fault.
+func (f *Fault) faultString() string {
+	if f.Description == "" {
+		return f.Code.String()
+	}
+
+	return f.Code.String() + ": " + f.Description
+}
+
+func (f *Fault) Unwrap() error {
+	return faultErrors[f.Code]
+}
+
+// asFault converts the xmlrpc fault inside err to *Fault, other errors are returned as is.
+func asFault(err error) error {
+	var fe xmlrpc.FaultError
+	if errors.As(err, &fe) {
+		return NewFault(fe.Code, fe.String)
+	}
+
+	return err
+}
+
+// FaultCodeOf returns the supervisord fault code carried by err.
+func FaultCodeOf(err error) (FaultCode, bool) {
+	var f *Fault
+	if errors.As(err, &f) {
+		return f.Code, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,75 @@
+package supervisord
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kolo/xmlrpc"
+	"github.com/stretchr/testify/suite"
+)
+
+type FaultSuite struct {
+	suite.Suite
+}
+
+func TestFault(t *testing.T) {
+	suite.Run(t, new(FaultSuite))
+}
+
+func (s *FaultSuite) Test_01_parse() {
+	f := NewFault(60, "ALREADY_STARTED: web:web_00")
+	s.Equal(FaultAlreadyStarted, f.Code)
+	s.Equal("web:web_00", f.Name)
+	s.Equal("web:web_00", f.Description)
+	s.ErrorIs(f, ErrAlreadyStarted)
+
+	f = NewFault(30, "FAILED: reason")
+	s.Equal("", f.Name)
+	s.Equal("reason", f.Description)
+
+	f = NewFault(6, "SHUTDOWN_STATE")
+	s.Equal("", f.Description)
+	s.Equal("Fault(6): SHUTDOWN_STATE", f.Error())
+}
+
+func (s *FaultSuite) Test_02_asFault() {
+	err := fmt.Errorf("wrapped: %w", xmlrpc.FaultError{Code: 70, String: "NOT_RUNNING: foo"})
+	err = asFault(err)
+	s.ErrorIs(err, ErrNotRunning)
+
+	code, ok := FaultCodeOf(err)
+	s.True(ok)
+	s.Equal(FaultNotRunning, code)
+
+	plain := errors.New("EOF")
+	s.Equal(plain, asFault(plain))
+}
+
+func (s *FaultSuite) Test_03_processResults() {
+	resp := `<?xml version="1.0"?><methodResponse><params><param><value><array><data>
+<value><struct>
+<member><name>name</name><value><string>web_00</string></value></member>
+<member><name>group</name><value><string>web</string></value></member>
+<member><name>status</name><value><int>80</int></value></member>
+<member><name>description</name><value><string>OK</string></value></member>
+</struct></value>
+<value><struct>
+<member><name>name</name><value><string>web_01</string></value></member>
+<member><name>group</name><value><string>web</string></value></member>
+<member><name>status</name><value><int>50</int></value></member>
+<member><name>description</name><value><string>SPAWN_ERROR: web:web_01</string></value></member>
+</struct></value>
+</data></array></value></param></params></methodResponse>`
+
+	var results ProcessResults
+	s.Nil(xmlrpc.Response(resp).Unmarshal(&results))
+	s.Len(results, 2)
+	s.Nil(results[0].Err())
+
+	err := results.Err()
+	s.ErrorIs(err, ErrSpawnError)
+	s.Equal("Fault(50): SPAWN_ERROR: web:web_01", err.Error())
+
+	s.Equal(results[1], newProcessResult("web", "web_01", NewFault(50, "SPAWN_ERROR: web:web_01")))
+}
@@ -0,0 +1,210 @@
+package supervisord
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// FlapOptions configures a FlapDetector.
+type FlapOptions struct {
+	Window         time.Duration   // sliding window restarts are counted in, 10 minutes when 0
+	Threshold      int             // restarts within Window which raise Flapping, 3 when 0
+	IgnoreExpected bool            // do not count exits with a status listed in the program's exitcodes
+	Configs        []ProcessConfig // provide the exitcodes of each program, exit status 0 is expected without them
+}
+
+// Flapping is raised when a process restarted Threshold times within Window.
+type Flapping struct {
+	Name         string
+	Restarts     int           // restarts within the window
+	Unexpected   int           // restarts whose exit status is not in the program's exitcodes
+	ExitStatuses []int         // exit statuses of the restarts within the window, oldest first
+	Window       time.Duration // the window of the detector
+	Time         time.Time     // time of the restart which raised it
+}
+
+func (f *Flapping) String() string {
+	return fmt.Sprintf("%s restarted %d times in %s (%d unexpected), exit statuses %v",
+		f.Name, f.Restarts, f.Window, f.Unexpected, f.ExitStatuses)
+}
+
+type restartRecord struct {
+	at         time.Time
+	exitStatus int
+	expected   bool
+}
+
+// FlapDetector tracks process restarts over a sliding window, it catches programs which
+// die and get restarted over and over without ever reaching FATAL.
+// It is fed with the events of Watch through Observe, or by any event source through Record.
+type FlapDetector struct {
+	opts FlapOptions
+
+	mu          sync.Mutex
+	exitcodes   map[string][]int
+	history     map[string][]restartRecord
+	restartedAt time.Time // time of the last ChangeSupervisorRestarted
+}
+
+func NewFlapDetector(opts FlapOptions) *FlapDetector {
+	if opts.Window <= 0 {
+		opts.Window = 10 * time.Minute
+	}
+
+	if opts.Threshold <= 0 {
+		opts.Threshold = 3
+	}
+
+	d := &FlapDetector{
+		opts:      opts,
+		exitcodes: make(map[string][]int, len(opts.Configs)),
+		history:   make(map[string][]restartRecord),
+	}
+
+	for _, pc := range opts.Configs {
+		d.exitcodes[pc.FullName()] = pc.Exitcodes
+	}
+
+	return d
+}
+
+// Observe feeds a watcher event, it returns a *Flapping when the event is a restart which
+// reaches the threshold, nil otherwise.
+//
+// A process died when it enters EXITED, BACKOFF or FATAL from another state, or when it runs
+// under a new pid, the restart happened between two polls then. New pids seen in the same poll
+// as a ChangeSupervisorRestarted are not counted, supervisord respawned all its processes.
+func (d *FlapDetector) Observe(ev StateChange) *Flapping {
+	if ev.Kind == ChangeSupervisorRestarted {
+		d.mu.Lock()
+		d.restartedAt = ev.Time
+		d.mu.Unlock()
+
+		return nil
+	}
+
+	if ev.New == nil || ev.Old == nil {
+		return nil
+	}
+
+	switch ev.Kind {
+	case ChangeState:
+		if !slices.Contains(exitedStates, ev.New.State) || slices.Contains(exitedStates, ev.Old.State) {
+			return nil
+		}
+	case ChangePID:
+		d.mu.Lock()
+		respawned := !ev.Time.IsZero() && ev.Time.Equal(d.restartedAt)
+		d.mu.Unlock()
+
+		if respawned {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	at := ev.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	return d.Record(ev.Name, at, ev.New.ExitStatus)
+}
+
+var exitedStates = []ProcessState{StateExited, StateBackoff, StateFatal}
+
+// Record adds a restart of name at the given time, e.g. from a PROCESS_STATE_EXITED event.
+func (d *FlapDetector) Record(name string, at time.Time, exitStatus int) *Flapping {
+	name = FullName(name)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	expected := d.expected(name, exitStatus)
+	if expected && d.opts.IgnoreExpected {
+		return nil
+	}
+
+	records := append(d.history[name], restartRecord{at: at, exitStatus: exitStatus, expected: expected})
+
+	since := at.Add(-d.opts.Window)
+	records = slices.DeleteFunc(records, func(r restartRecord) bool {
+		return r.at.Before(since)
+	})
+
+	d.history[name] = records
+
+	if len(records) < d.opts.Threshold {
+		return nil
+	}
+
+	f := &Flapping{Name: name, Restarts: len(records), Window: d.opts.Window, Time: at}
+
+	for _, r := range records {
+		f.ExitStatuses = append(f.ExitStatuses, r.exitStatus)
+		if !r.expected {
+			f.Unexpected++
+		}
+	}
+
+	return f
+}
+
+// Reset forgets the restarts of name, e.g. after it was fixed and redeployed.
+func (d *FlapDetector) Reset(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.history, FullName(name))
+}
+
+// expected reports whether exitStatus is listed in the exitcodes of the program.
+func (d *FlapDetector) expected(name string, exitStatus int) bool {
+	codes, ok := d.exitcodes[name]
+	if !ok {
+		return exitStatus == 0
+	}
+
+	return slices.Contains(codes, exitStatus)
+}
+
+// DetectFlapping watches the processes selected by sel and emits Flapping signals. The exitcodes
+// of the programs are fetched with GetAllConfigInfo unless opts.Configs is set.
+// The channel is closed when ctx is done.
+func (c *Client) DetectFlapping(ctx context.Context, interval time.Duration, sel *Selector, opts FlapOptions) (<-chan Flapping, error) {
+	if opts.Configs == nil {
+		configs, err := c.GetAllConfigInfoContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Configs = configs
+	}
+
+	d := NewFlapDetector(opts)
+	events := c.Watch(ctx, interval, sel)
+	ch := make(chan Flapping)
+
+	go func() {
+		defer close(ch)
+
+		for ev := range events {
+			f := d.Observe(ev)
+			if f == nil {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- *f:
+			}
+		}
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,103 @@
+package supervisord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FlappingSuite struct {
+	suite.Suite
+	start time.Time
+}
+
+func TestFlapping(t *testing.T) {
+	suite.Run(t, new(FlappingSuite))
+}
+
+func (s *FlappingSuite) SetupTest() {
+	s.start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *FlappingSuite) exited(minute int, exitStatus int) StateChange {
+	return StateChange{
+		Change: Change{
+			Kind: ChangeState,
+			Name: "worker:worker",
+			Old:  &ProcessInfo{Group: "worker", Name: "worker", State: StateRunning, Pid: 10},
+			New:  &ProcessInfo{Group: "worker", Name: "worker", State: StateExited, ExitStatus: exitStatus},
+		},
+		Time: s.start.Add(time.Duration(minute) * time.Minute),
+	}
+}
+
+func (s *FlappingSuite) Test_01_window() {
+	d := NewFlapDetector(FlapOptions{
+		Window:    5 * time.Minute,
+		Threshold: 3,
+		Configs:   []ProcessConfig{{Group: "worker", Name: "worker", Exitcodes: []int{0, 2}}},
+	})
+
+	s.Nil(d.Observe(s.exited(0, 1)))
+	s.Nil(d.Observe(s.exited(1, 2)))
+
+	f := d.Observe(s.exited(2, 0))
+	s.NotNil(f)
+	s.Equal(3, f.Restarts)
+	s.Equal(1, f.Unexpected)
+	s.Equal([]int{1, 2, 0}, f.ExitStatuses)
+
+	// the first two restarts left the window
+	s.Nil(d.Observe(s.exited(7, 1)))
+}
+
+func (s *FlappingSuite) Test_02_ignore() {
+	d := NewFlapDetector(FlapOptions{Threshold: 2, IgnoreExpected: true})
+
+	s.Nil(d.Observe(s.exited(0, 0)))
+	s.Nil(d.Observe(s.exited(1, 0)))
+	s.Nil(d.Observe(s.exited(2, 1)))
+	s.NotNil(d.Observe(s.exited(3, 1)))
+
+	stopped := s.exited(4, 0)
+	stopped.New.State = StateStopped
+	s.Nil(d.Observe(stopped))
+}
+
+func (s *FlappingSuite) respawned(minute int) StateChange {
+	return StateChange{
+		Change: Change{
+			Kind: ChangePID,
+			Name: "worker:worker",
+			Old:  &ProcessInfo{Group: "worker", Name: "worker", State: StateRunning, Pid: 10},
+			New:  &ProcessInfo{Group: "worker", Name: "worker", State: StateRunning, Pid: 11, ExitStatus: 1},
+		},
+		Time: s.start.Add(time.Duration(minute) * time.Minute),
+	}
+}
+
+func (s *FlappingSuite) Test_03_pid() {
+	d := NewFlapDetector(FlapOptions{Threshold: 2})
+
+	s.Nil(d.Observe(s.respawned(0)))
+
+	f := d.Observe(s.respawned(1))
+	s.NotNil(f)
+	s.Equal(2, f.Restarts)
+	s.Equal([]int{1, 1}, f.ExitStatuses)
+}
+
+func (s *FlappingSuite) Test_04_supervisorRestarted() {
+	d := NewFlapDetector(FlapOptions{Threshold: 2})
+
+	for minute := range 3 {
+		restarted := StateChange{Change: Change{Kind: ChangeSupervisorRestarted}, Time: s.start.Add(time.Duration(minute) * time.Minute)}
+		s.Nil(d.Observe(restarted))
+		s.Nil(d.Observe(s.respawned(minute)))
+	}
+
+	// a later poll counts again
+	s.Nil(d.Observe(s.respawned(3)))
+	s.NotNil(d.Observe(s.respawned(4)))
+}
@@ -0,0 +1,214 @@
+package supervisord
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"syscall"
+	"time"
+)
+
+type StopStepKind string
+
+const (
+	StopStepSignal StopStepKind = "signal" // the drain signal was sent
+	StopStepDrain  StopStepKind = "drain"  // the drain period ended, or the process exited during it
+	StopStepStop   StopStepKind = "stop"   // the stopsignal was sent and the process exited, or StopTimeout ran out
+	StopStepKill   StopStepKind = "kill"   // SIGKILL was sent
+	StopStepWait   StopStepKind = "wait"   // the process exited after SIGKILL, or StopTimeout ran out
+)
+
+// StopStep reports one step of GracefulStop.
+type StopStep struct {
+	Kind    StopStepKind
+	State   ProcessState  // state of the process after the step
+	Elapsed time.Duration // time since GracefulStop started
+	Err     error         // failure of the step, the next step is still tried
+}
+
+// GracefulStopOptions configures GracefulStop.
+type GracefulStopOptions struct {
+	Signal      syscall.Signal // drain signal such as SIGUSR1, no signal and no drain period when 0
+	Drain       time.Duration  // how long the process may take to finish after Signal
+	StopTimeout time.Duration  // how long the process may take to exit after its stopsignal, then after SIGKILL, 10s when 0
+	OnStep      func(StopStep) // called after each step, optional
+}
+
+var stoppedStates = []ProcessState{StateStopped, StateExited, StateFatal}
+
+// GracefulStop stops a process in steps: it sends opts.Signal and waits up to opts.Drain for the
+// process to finish its work, then sends the configured stopsignal of the program and finally
+// SIGKILL when the process is still alive after opts.StopTimeout. The signals are sent with
+// SignalProcess while the process is RUNNING, supervisord refuses to signal it once StopProcess
+// put it in STOPPING. StopProcess is called last to settle the state, so autorestart does not
+// bring the process back. The steps taken are returned, they are also passed to opts.OnStep
+// as they happen.
+func (c *Client) GracefulStop(ctx context.Context, name string, opts GracefulStopOptions) ([]StopStep, error) {
+	name = FullName(name)
+	begin := time.Now()
+
+	if opts.StopTimeout <= 0 {
+		opts.StopTimeout = 10 * time.Second
+	}
+
+	var steps []StopStep
+
+	report := func(kind StopStepKind, state ProcessState, err error) {
+		step := StopStep{Kind: kind, State: state, Elapsed: time.Since(begin), Err: err}
+		steps = append(steps, step)
+
+		if opts.OnStep != nil {
+			opts.OnStep(step)
+		}
+	}
+
+	pi, err := c.GetProcessInfoContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(stoppedStates, pi.State) {
+		return nil, nil
+	}
+
+	if opts.Signal != 0 {
+		err := c.SignalProcessContext(ctx, name, opts.Signal)
+		report(StopStepSignal, pi.State, err)
+
+		if err == nil && opts.Drain > 0 {
+			state, err := c.drain(ctx, name, opts.Drain)
+			report(StopStepDrain, state, err)
+		}
+	}
+
+	stopsignal, err := c.stopSignal(ctx, name)
+	if err != nil {
+		return steps, err
+	}
+
+	state, err := c.signalAndWait(ctx, name, stopsignal, opts.StopTimeout)
+	if err == nil {
+		state, err = c.settle(ctx, name, state)
+		report(StopStepStop, state, err)
+
+		return steps, err
+	}
+
+	report(StopStepStop, state, err)
+
+	if err := ctx.Err(); err != nil {
+		return steps, err
+	}
+
+	err = c.SignalProcessContext(ctx, name, syscall.SIGKILL)
+	if errors.Is(err, ErrNotRunning) {
+		// exited right after the stop step gave up
+		err = nil
+	}
+
+	report(StopStepKill, state, err)
+
+	if err != nil {
+		return steps, err
+	}
+
+	state, err = c.waitGone(ctx, name, opts.StopTimeout)
+	if err == nil {
+		state, err = c.settle(ctx, name, state)
+	}
+
+	report(StopStepWait, state, err)
+
+	return steps, err
+}
+
+// stopSignal returns the configured stopsignal of the process, SIGTERM when it has none.
+func (c *Client) stopSignal(ctx context.Context, name string) (syscall.Signal, error) {
+	configs, err := c.GetAllConfigInfoContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, pc := range configs {
+		if pc.FullName() == name && pc.Stopsignal != 0 {
+			return syscall.Signal(pc.Stopsignal), nil
+		}
+	}
+
+	return syscall.SIGTERM, nil
+}
+
+// signalAndWait sends sig and waits up to d for the process to exit, see waitGone.
+func (c *Client) signalAndWait(ctx context.Context, name string, sig syscall.Signal, d time.Duration) (ProcessState, error) {
+	// NOT_RUNNING: exited while draining, or is already being stopped by someone else
+	if err := c.SignalProcessContext(ctx, name, sig); err != nil && !errors.Is(err, ErrNotRunning) {
+		return StateUnknown, err
+	}
+
+	return c.waitGone(ctx, name, d)
+}
+
+// waitGone waits up to d for the process to exit and returns its last state. A new pid counts as
+// exited, autorestart may have spawned the process again before it was seen stopped.
+func (c *Client) waitGone(ctx context.Context, name string, d time.Duration) (ProcessState, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	interval := c.pollInterval()
+	state, pid := StateUnknown, -1
+
+	for {
+		pi, err := c.GetProcessInfoContext(waitCtx, name)
+		if err != nil {
+			return state, err
+		}
+
+		if slices.Contains(stoppedStates, pi.State) || (pid != -1 && pi.Pid != pid) {
+			return pi.State, nil
+		}
+
+		state = pi.State
+		if pid == -1 {
+			pid = pi.Pid
+		}
+
+		if err := sleepContext(waitCtx, interval); err != nil {
+			return state, err
+		}
+
+		interval = min(interval*2, _maxPollInterval)
+	}
+}
+
+// settle calls StopProcess once the process exited, so supervisord records it as STOPPED
+// and does not restart it.
+func (c *Client) settle(ctx context.Context, name string, state ProcessState) (ProcessState, error) {
+	err := c.StopProcessContext(ctx, name, true)
+
+	switch {
+	case errors.Is(err, ErrNotRunning):
+		return state, nil
+	case err != nil:
+		return state, err
+	}
+
+	return StateStopped, nil
+}
+
+// drain waits up to d for the process to stop by itself and returns its last state.
+func (c *Client) drain(ctx context.Context, name string, d time.Duration) (ProcessState, error) {
+	drainCtx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	pi, err := c.WaitForState(drainCtx, name, stoppedStates...)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		// still running at the end of the drain period, the stop step takes over.
+		err = nil
+	}
+
+	if pi == nil {
+		return StateUnknown, err
+	}
+
+	return pi.State, err
+}
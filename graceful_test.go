@@ -0,0 +1,108 @@
+package supervisord
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GracefulSuite struct {
+	suite.Suite
+	fake   *fakeSupervisor
+	worker *fakeProcess
+	c      *Client
+}
+
+func TestGraceful(t *testing.T) {
+	suite.Run(t, new(GracefulSuite))
+}
+
+func (s *GracefulSuite) SetupTest() {
+	s.worker = newFakeProcess("worker", "worker", StateRunning)
+	s.fake = newFakeSupervisor(s.T(), s.worker, newFakeProcess("cron", "cron", StateStopped))
+	s.c = s.fake.client()
+}
+
+func (s *GracefulSuite) kinds(steps []StopStep) []StopStepKind {
+	var kinds []StopStepKind
+	for _, step := range steps {
+		kinds = append(kinds, step.Kind)
+	}
+
+	return kinds
+}
+
+func (s *GracefulSuite) Test_01_escalation() {
+	s.worker.ignoreStop = true
+
+	var seen []StopStepKind
+
+	steps, err := s.c.GracefulStop(context.Background(), "worker", GracefulStopOptions{
+		Signal:      syscall.SIGUSR1,
+		Drain:       30 * time.Millisecond,
+		StopTimeout: 50 * time.Millisecond,
+		OnStep: func(step StopStep) {
+			seen = append(seen, step.Kind)
+		},
+	})
+	s.Nil(err)
+	s.Equal([]StopStepKind{StopStepSignal, StopStepDrain, StopStepStop, StopStepKill, StopStepWait}, s.kinds(steps))
+	s.Equal(seen, s.kinds(steps))
+
+	s.Nil(steps[1].Err)
+	s.Equal(StateRunning, steps[1].State)
+
+	s.ErrorIs(steps[2].Err, context.DeadlineExceeded)
+	s.Equal(StateRunning, steps[2].State)
+
+	s.Nil(steps[3].Err)
+	s.Equal([]string{
+		"worker:worker user defined signal 1",
+		"worker:worker terminated",
+		"worker:worker killed",
+	}, s.fake.signals)
+
+	s.Nil(steps[4].Err)
+	s.Equal(StateExited, steps[4].State)
+	s.Less(steps[4].Elapsed, time.Second)
+	s.Equal(-9, s.fake.info("worker").ExitStatus)
+	s.Len(s.fake.called("supervisor.stopProcess"), 1)
+}
+
+func (s *GracefulSuite) Test_02_drained() {
+	s.worker.exitOn = syscall.SIGUSR1
+
+	steps, err := s.c.GracefulStop(context.Background(), "worker", GracefulStopOptions{
+		Signal: syscall.SIGUSR1,
+		Drain:  time.Second,
+	})
+	s.Nil(err)
+	s.Equal([]StopStepKind{StopStepSignal, StopStepDrain, StopStepStop}, s.kinds(steps))
+	s.Equal(StateExited, steps[1].State)
+	s.Less(steps[1].Elapsed, time.Second)
+	s.Equal(StateExited, steps[2].State)
+	s.Equal([]string{"worker:worker user defined signal 1"}, s.fake.signals)
+	s.Len(s.fake.called("supervisor.stopProcess"), 1)
+}
+
+func (s *GracefulSuite) Test_03_stopped() {
+	steps, err := s.c.GracefulStop(context.Background(), "cron", GracefulStopOptions{Signal: syscall.SIGUSR1})
+	s.Nil(err)
+	s.Nil(steps)
+	s.Empty(s.fake.called("supervisor.signalProcess"))
+}
+
+func (s *GracefulSuite) Test_04_stopsignal() {
+	s.worker.config.Stopsignal = int(syscall.SIGINT)
+
+	steps, err := s.c.GracefulStop(context.Background(), "worker", GracefulStopOptions{StopTimeout: time.Second})
+	s.Nil(err)
+	s.Equal([]StopStepKind{StopStepStop}, s.kinds(steps))
+	s.Nil(steps[0].Err)
+	s.Equal(StateExited, steps[0].State)
+	s.Less(steps[0].Elapsed, time.Second)
+	s.Equal([]string{"worker:worker interrupt"}, s.fake.signals)
+}
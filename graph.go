@@ -0,0 +1,232 @@
+package supervisord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+var ErrCycle = errors.New("dependency cycle")
+
+// CycleError lists the programs forming a dependency cycle, it matches ErrCycle with errors.Is.
+type CycleError struct {
+	Cycle []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrCycle, strings.Join(e.Cycle, " -> "))
+}
+
+func (e *CycleError) Unwrap() error {
+	return ErrCycle
+}
+
+// Graph declares start dependencies between processes, which supervisor itself cannot express.
+//
+// Example:
+//
+//	g := NewGraph().
+//		Add("redis").
+//		Add("api", "redis").
+//		Add("worker", "api")
+//
+//	res, err := c.StartGraph(ctx, g)
+type Graph struct {
+	deps map[string][]string
+}
+
+func NewGraph() *Graph {
+	return &Graph{deps: make(map[string][]string)}
+}
+
+// Add declares name and the processes it depends on, names are normalized to "group:name".
+func (g *Graph) Add(name string, dependsOn ...string) *Graph {
+	name = FullName(name)
+
+	for _, dep := range dependsOn {
+		dep = FullName(dep)
+
+		if _, ok := g.deps[dep]; !ok {
+			g.deps[dep] = nil
+		}
+
+		if !slices.Contains(g.deps[name], dep) {
+			g.deps[name] = append(g.deps[name], dep)
+		}
+	}
+
+	if _, ok := g.deps[name]; !ok {
+		g.deps[name] = nil
+	}
+
+	return g
+}
+
+// Levels returns the processes in start order, the processes of a level only depend on
+// earlier levels and can be started in parallel. Names inside a level are sorted.
+func (g *Graph) Levels() ([][]string, error) {
+	pending := make(map[string]int, len(g.deps))
+	dependents := make(map[string][]string)
+
+	for name, deps := range g.deps {
+		pending[name] = len(deps)
+		for _, dep := range deps {
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	var levels [][]string
+
+	for len(pending) > 0 {
+		var level []string
+
+		for name, n := range pending {
+			if n == 0 {
+				level = append(level, name)
+			}
+		}
+
+		if len(level) == 0 {
+			return nil, &CycleError{Cycle: g.findCycle(pending)}
+		}
+
+		slices.Sort(level)
+
+		for _, name := range level {
+			delete(pending, name)
+
+			for _, d := range dependents[name] {
+				pending[d]--
+			}
+		}
+
+		levels = append(levels, level)
+	}
+
+	return levels, nil
+}
+
+// findCycle walks dependencies among the unresolved names until one repeats.
+func (g *Graph) findCycle(pending map[string]int) []string {
+	names := sortedKeys(pending)
+
+	path := []string{names[0]}
+	seen := map[string]int{names[0]: 0}
+
+	for {
+		last := path[len(path)-1]
+
+		var next string
+
+		for _, dep := range g.deps[last] {
+			if _, ok := pending[dep]; ok {
+				next = dep
+				break
+			}
+		}
+
+		if i, ok := seen[next]; ok {
+			return append(path[i:], next)
+		}
+
+		seen[next] = len(path)
+		path = append(path, next)
+	}
+}
+
+// DOT renders the graph in graphviz format, edges point from a process to its dependencies.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph supervisord {\n")
+
+	for _, name := range sortedKeys(g.deps) {
+		fmt.Fprintf(&b, "\t%q;\n", name)
+
+		deps := slices.Clone(g.deps[name])
+		slices.Sort(deps)
+
+		for _, dep := range deps {
+			fmt.Fprintf(&b, "\t%q -> %q;\n", name, dep)
+		}
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// StartGraph starts the processes of g level by level, waiting for each level to be RUNNING
+// before starting the next one. Processes already running are fine. It stops at the first
+// level with a failure and returns the results of the levels attempted.
+func (c *Client) StartGraph(ctx context.Context, g *Graph, opts ...BulkOptions) (BulkResult, error) {
+	levels, err := g.Levels()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.runLevels(ctx, levels, c.startRunning, opts...)
+}
+
+// StopGraph stops the processes of g in reverse dependency order, a process is only stopped once
+// everything depending on it is stopped. Processes not running are fine.
+func (c *Client) StopGraph(ctx context.Context, g *Graph, opts ...BulkOptions) (BulkResult, error) {
+	levels, err := g.Levels()
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(levels)
+
+	return c.runLevels(ctx, levels, c.stopStopped, opts...)
+}
+
+// startRunning starts the process unless it already runs and waits for it to be RUNNING.
+func (c *Client) startRunning(ctx context.Context, name string) error {
+	if err := c.StartProcessContext(ctx, name, true); err != nil && !errors.Is(err, ErrAlreadyStarted) {
+		return err
+	}
+
+	_, err := c.WaitForState(ctx, name, StateRunning)
+
+	return err
+}
+
+// stopStopped stops the process waiting for it, a process which is not running is fine.
+func (c *Client) stopStopped(ctx context.Context, name string) error {
+	if err := c.StopProcessContext(ctx, name, true); err != nil && !errors.Is(err, ErrNotRunning) {
+		return err
+	}
+
+	return nil
+}
+
+// runLevels runs fn for the processes of each level concurrently, stopping after a failed level.
+func (c *Client) runLevels(ctx context.Context, levels [][]string, fn func(ctx context.Context, name string) error, opts ...BulkOptions) (BulkResult, error) {
+	all := make(BulkResult)
+
+	for _, level := range levels {
+		res := c.Bulk(ctx, level, fn, opts...)
+		maps.Copy(all, res)
+
+		if err := res.Err(); err != nil {
+			return all, err
+		}
+	}
+
+	return all, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
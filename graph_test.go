@@ -0,0 +1,49 @@
+package supervisord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GraphSuite struct {
+	suite.Suite
+}
+
+func TestGraph(t *testing.T) {
+	suite.Run(t, new(GraphSuite))
+}
+
+func (s *GraphSuite) Test_01_levels() {
+	g := NewGraph().
+		Add("redis").
+		Add("postgres").
+		Add("api", "redis", "postgres").
+		Add("web:web_00", "api").
+		Add("worker", "redis")
+
+	levels, err := g.Levels()
+	s.Nil(err)
+	s.Equal([][]string{
+		{"postgres:postgres", "redis:redis"},
+		{"api:api", "worker:worker"},
+		{"web:web_00"},
+	}, levels)
+}
+
+func (s *GraphSuite) Test_02_cycle() {
+	g := NewGraph().
+		Add("a", "b").
+		Add("b", "c").
+		Add("c", "a").
+		Add("d")
+
+	_, err := g.Levels()
+	s.ErrorIs(err, ErrCycle)
+	s.Equal("dependency cycle: a:a -> b:b -> c:c -> a:a", err.Error())
+}
+
+func (s *GraphSuite) Test_03_dot() {
+	g := NewGraph().Add("api", "redis")
+	s.Equal("digraph supervisord {\n\t\"api:api\";\n\t\"api:api\" -> \"redis:redis\";\n\t\"redis:redis\";\n}\n", g.DOT())
+}
@@ -0,0 +1,226 @@
+package supervisord
+
+import (
+	"context"
+	"io"
+	"strings"
+	"syscall"
+)
+
+// SplitName splits a supervisor name spec into group and process name,
+// a bare "name" refers to the process of the same name in the group of the same name.
+func SplitName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+
+	group, process, found := strings.Cut(name, ":")
+	if !found {
+		return name, name
+	}
+
+	return group, process
+}
+
+// FullName returns the normalized "group:name" form of a name spec.
+func FullName(name string) string {
+	group, process := SplitName(name)
+	return group + ":" + process
+}
+
+// FullName returns "group:name" of the process.
+func (pi ProcessInfo) FullName() string {
+	return pi.Group + ":" + pi.Name
+}
+
+// FullName returns "group:name" of the process.
+func (pc ProcessConfig) FullName() string {
+	return pc.Group + ":" + pc.Name
+}
+
+// Process is a handle to one process, it carries the normalized "group:name".
+type Process struct {
+	c     *Client
+	group string
+	name  string
+}
+
+// Process returns a handle to the process named by name, either "group:name" or "name".
+func (c *Client) Process(name string) *Process {
+	group, process := SplitName(name)
+	return &Process{c: c, group: group, name: process}
+}
+
+func (p *Process) Name() string {
+	return p.name
+}
+
+func (p *Process) Group() string {
+	return p.group
+}
+
+// FullName returns "group:name", the form every call of the handle uses.
+func (p *Process) FullName() string {
+	return p.group + ":" + p.name
+}
+
+func (p *Process) String() string {
+	return p.FullName()
+}
+
+func (p *Process) Info(ctx context.Context) (*ProcessInfo, error) {
+	return p.c.GetProcessInfoContext(ctx, p.FullName())
+}
+
+// Config returns the config of the process, a BAD_NAME fault when supervisord does not know it.
+func (p *Process) Config(ctx context.Context) (*ProcessConfig, error) {
+	configs, err := p.c.GetAllConfigInfoContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range configs {
+		if configs[i].Group == p.group && configs[i].Name == p.name {
+			return &configs[i], nil
+		}
+	}
+
+	return nil, &Fault{Code: FaultBadName, Name: p.FullName(), Description: p.FullName()}
+}
+
+func (p *Process) Start(ctx context.Context, wait bool) error {
+	return p.c.StartProcessContext(ctx, p.FullName(), wait)
+}
+
+func (p *Process) Stop(ctx context.Context, wait bool) error {
+	return p.c.StopProcessContext(ctx, p.FullName(), wait)
+}
+
+// Restart stops the process, tolerating it was not running, and starts it again waiting for both.
+func (p *Process) Restart(ctx context.Context) (*RestartResult, error) {
+	return p.c.RestartProcessContext(ctx, p.FullName())
+}
+
+func (p *Process) Signal(ctx context.Context, signal syscall.Signal) error {
+	return p.c.SignalProcessContext(ctx, p.FullName(), signal)
+}
+
+func (p *Process) SendStdin(ctx context.Context, chars string) error {
+	return p.c.SendProcessStdinContext(ctx, p.FullName(), chars)
+}
+
+func (p *Process) ClearLogs(ctx context.Context) error {
+	return p.c.ClearProcessLogsContext(ctx, p.FullName())
+}
+
+// Stdout returns a reader of the stdout log from its beginning, it returns io.EOF at the current end.
+func (p *Process) Stdout(ctx context.Context) *LogReader {
+	return &LogReader{ctx: ctx, name: p.FullName(), read: p.c.ReadProcessStdoutLogContext}
+}
+
+// Stderr returns a reader of the stderr log from its beginning, it returns io.EOF at the current end.
+func (p *Process) Stderr(ctx context.Context) *LogReader {
+	return &LogReader{ctx: ctx, name: p.FullName(), read: p.c.ReadProcessStderrLogContext}
+}
+
+// LogReader reads a process log through readProcessStdoutLog/readProcessStderrLog.
+// Reading again after io.EOF returns what was logged since.
+type LogReader struct {
+	ctx    context.Context //nolint:containedctx
+	name   string
+	offset int
+	read   func(ctx context.Context, name string, offset, length int) (string, error)
+}
+
+func (r *LogReader) Read(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	data, err := r.read(r.ctx, r.name, r.offset, len(b))
+	if err != nil {
+		return 0, err
+	}
+
+	if data == "" {
+		return 0, io.EOF
+	}
+
+	n := copy(b, data)
+	r.offset += n
+
+	return n, nil
+}
+
+// Offset returns the log offset the next Read starts at.
+func (r *LogReader) Offset() int {
+	return r.offset
+}
+
+// Group is a handle to a process group.
+type Group struct {
+	c    *Client
+	name string
+}
+
+// Group returns a handle to the group name, "name:*" and "name:" are accepted too.
+func (c *Client) Group(name string) *Group {
+	group, _ := SplitName(name)
+	return &Group{c: c, name: group}
+}
+
+func (g *Group) Name() string {
+	return g.name
+}
+
+func (g *Group) String() string {
+	return g.name + ":*"
+}
+
+// Process returns a handle to the member name of the group.
+func (g *Group) Process(name string) *Process {
+	return &Process{c: g.c, group: g.name, name: name}
+}
+
+// Processes returns the info of all members of the group.
+func (g *Group) Processes(ctx context.Context) ([]ProcessInfo, error) {
+	all, err := g.c.GetAllProcessInfoContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []ProcessInfo
+
+	for _, pi := range all {
+		if pi.Group == g.name {
+			members = append(members, pi)
+		}
+	}
+
+	return members, nil
+}
+
+func (g *Group) Start(ctx context.Context, wait bool) (ProcessResults, error) {
+	return g.c.StartProcessGroupContext(ctx, g.name, wait)
+}
+
+func (g *Group) Stop(ctx context.Context, wait bool) (ProcessResults, error) {
+	return g.c.StopProcessGroupContext(ctx, g.name, wait)
+}
+
+// Restart stops all members and starts them again, waiting for both.
+func (g *Group) Restart(ctx context.Context) ([]RestartResult, error) {
+	return g.c.RestartProcessGroupContext(ctx, g.name)
+}
+
+func (g *Group) Signal(ctx context.Context, signal syscall.Signal) (ProcessResults, error) {
+	return g.c.SignalProcessGroupContext(ctx, g.name, signal)
+}
+
+// Add activates the group after its config was added and ReloadConfig was called.
+func (g *Group) Add(ctx context.Context) error {
+	return g.c.AddProcessGroupContext(ctx, g.name)
+}
+
+// Remove removes the stopped group from the active config.
+func (g *Group) Remove(ctx context.Context) error {
+	return g.c.RemoveProcessGroupContext(ctx, g.name)
+}
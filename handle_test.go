@@ -0,0 +1,110 @@
+package supervisord
+
+import (
+	"context"
+	"io"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HandleSuite struct {
+	suite.Suite
+	fake *fakeSupervisor
+	c    *Client
+	ctx  context.Context
+}
+
+func TestHandle(t *testing.T) {
+	suite.Run(t, new(HandleSuite))
+}
+
+func (s *HandleSuite) SetupTest() {
+	cron := newFakeProcess("cron", "cron", StateStopped)
+	cron.stdout = "started\nworking\n"
+
+	s.fake = newFakeSupervisor(s.T(),
+		cron,
+		newFakeProcess("web", "web_00", StateRunning),
+		newFakeProcess("web", "web_01", StateRunning),
+	)
+	s.c = s.fake.client()
+	s.ctx = context.Background()
+}
+
+func (s *HandleSuite) Test_01_names() {
+	s.Equal("cron", s.c.Process("cron").Group())
+	s.Equal("web:web_00", s.c.Process(" web:web_00 ").FullName())
+	s.Equal("web:*", s.c.Group("web:*").String())
+	s.Equal("web:web_01", s.c.Group("web:").Process("web_01").FullName())
+}
+
+func (s *HandleSuite) Test_02_wire() {
+	s.Nil(s.c.Process("cron").Start(s.ctx, true))
+	s.Nil(s.c.Process("cron").Signal(s.ctx, syscall.SIGHUP))
+
+	pi, err := s.c.Process("cron").Info(s.ctx)
+	s.Nil(err)
+	s.Equal(StateRunning, pi.State)
+
+	s.Equal([]string{"supervisor.startProcess cron:cron"}, s.fake.called("supervisor.startProcess"))
+	s.Equal([]string{"supervisor.signalProcess cron:cron"}, s.fake.called("supervisor.signalProcess"))
+	s.Equal([]string{"supervisor.getProcessInfo cron:cron"}, s.fake.called("supervisor.getProcessInfo"))
+}
+
+func (s *HandleSuite) Test_03_config() {
+	pc, err := s.c.Process("web:web_01").Config(s.ctx)
+	s.Nil(err)
+	s.Equal("web:web_01", pc.FullName())
+
+	_, err = s.c.Process("web:web_02").Config(s.ctx)
+	s.ErrorIs(err, ErrBadName)
+
+	var f *Fault
+	s.ErrorAs(err, &f)
+	s.Equal("web:web_02", f.Name)
+}
+
+func (s *HandleSuite) Test_04_logReader() {
+	r := s.c.Process("cron").Stdout(s.ctx)
+	buf := make([]byte, 10)
+
+	n, err := r.Read(buf)
+	s.Nil(err)
+	s.Equal("started\nwo", string(buf[:n]))
+	s.Equal(10, r.Offset())
+
+	rest, err := io.ReadAll(r)
+	s.Nil(err)
+	s.Equal("rking\n", string(rest))
+	s.Equal(16, r.Offset())
+
+	_, err = r.Read(buf)
+	s.ErrorIs(err, io.EOF)
+
+	s.fake.set(func() { s.fake.find("cron").stdout += "done\n" })
+
+	n, err = r.Read(buf)
+	s.Nil(err)
+	s.Equal("done\n", string(buf[:n]))
+}
+
+func (s *HandleSuite) Test_05_groupProcesses() {
+	members, err := s.c.Group("web").Processes(s.ctx)
+	s.Nil(err)
+	s.Len(members, 2)
+
+	for _, pi := range members {
+		s.Equal("web", pi.Group)
+	}
+
+	members, err = s.c.Group("nope").Processes(s.ctx)
+	s.Nil(err)
+	s.Empty(members)
+
+	results, err := s.c.Group("web").Stop(s.ctx, true)
+	s.Nil(err)
+	s.Nil(results.Err())
+	s.Equal([]string{"supervisor.stopProcessGroup web"}, s.fake.called("supervisor.stopProcessGroup"))
+}
@@ -0,0 +1,40 @@
+package supervisord
+
+import "context"
+
+// Invoker sends an xmlrpc call, method is the full name such as "supervisor.getState".
+type Invoker func(ctx context.Context, method string, args, reply any) error
+
+// Interceptor wraps every call of the client, including Multicall and system.* methods.
+// It must call next to send the call, or return without calling it to short-circuit.
+//
+// Example:
+//
+//	audit := func(ctx context.Context, method string, args, reply any, next Invoker) error {
+//		err := next(ctx, method, args, reply)
+//		log.Info().Str("method", method).Err(err).Msg("audit")
+//
+//		return err
+//	}
+//
+//	c, err := NewClient(url, WithInterceptor(audit))
+type Interceptor func(ctx context.Context, method string, args, reply any, next Invoker) error
+
+// WithInterceptor appends interceptors to the chain, the first one added is the outermost.
+func WithInterceptor(ics ...Interceptor) ClientOptions {
+	return func(o *Client) {
+		o.interceptors = append(o.interceptors, ics...)
+	}
+}
+
+// chainInterceptors returns an invoker running ics in order before invoker.
+func chainInterceptors(invoker Invoker, ics []Interceptor) Invoker {
+	for i := len(ics) - 1; i >= 0; i-- {
+		ic, next := ics[i], invoker
+		invoker = func(ctx context.Context, method string, args, reply any) error {
+			return ic(ctx, method, args, reply, next)
+		}
+	}
+
+	return invoker
+}
@@ -1,6 +1,7 @@
 package supervisord
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -14,15 +15,27 @@ func TailLogError(op string) error {
 }
 
 func (c *Client) ReadProcessStdoutLog(name string, offset, length int) (string, error) {
-	return c.CallAsStr(readProcessStdoutLog, name, offset, length)
+	return c.ReadProcessStdoutLogContext(context.Background(), name, offset, length)
+}
+
+func (c *Client) ReadProcessStdoutLogContext(ctx context.Context, name string, offset, length int) (string, error) {
+	return c.CallAsStrContext(ctx, readProcessStdoutLog, name, offset, length)
 }
 
 func (c *Client) ReadProcessStderrLog(name string, offset, length int) (string, error) {
-	return c.CallAsStr(readProcessStderrLog, name, offset, length)
+	return c.ReadProcessStderrLogContext(context.Background(), name, offset, length)
+}
+
+func (c *Client) ReadProcessStderrLogContext(ctx context.Context, name string, offset, length int) (string, error) {
+	return c.CallAsStrContext(ctx, readProcessStderrLog, name, offset, length)
 }
 
 func (c *Client) TailProcessStdoutLog(name string, offset int64, length int) (string, int64, bool, error) {
-	replies, err := c.CallAsInterfaceArray(tailProcessStdoutLog, name, offset, length)
+	return c.TailProcessStdoutLogContext(context.Background(), name, offset, length)
+}
+
+func (c *Client) TailProcessStdoutLogContext(ctx context.Context, name string, offset int64, length int) (string, int64, bool, error) {
+	replies, err := c.CallAsInterfaceArrayContext(ctx, tailProcessStdoutLog, name, offset, length)
 	if err != nil {
 		return "", 0, false, fmt.Errorf("cannot get stdoutlog: %w", err)
 	}
@@ -50,7 +63,11 @@ func (c *Client) fmtTail(replies []interface{}) (string, int64, bool, error) {
 }
 
 func (c *Client) TailProcessStderrLog(name string, offset int64, length int) (string, int64, bool, error) {
-	replies, err := c.CallAsInterfaceArray(tailProcessStderrLog, name, offset, length)
+	return c.TailProcessStderrLogContext(context.Background(), name, offset, length)
+}
+
+func (c *Client) TailProcessStderrLogContext(ctx context.Context, name string, offset int64, length int) (string, int64, bool, error) {
+	replies, err := c.CallAsInterfaceArrayContext(ctx, tailProcessStderrLog, name, offset, length)
 	if err != nil {
 		return "", 0, false, fmt.Errorf("cannot get process Stderrlog: %w", err)
 	}
@@ -59,11 +76,19 @@ func (c *Client) TailProcessStderrLog(name string, offset int64, length int) (st
 }
 
 func (c *Client) ClearProcessLogs(name string) error {
-	return c.CallAsBool(clearProcessLogs, name)
+	return c.ClearProcessLogsContext(context.Background(), name)
+}
+
+func (c *Client) ClearProcessLogsContext(ctx context.Context, name string) error {
+	return c.CallAsBoolContext(ctx, clearProcessLogs, name)
 }
 
-func (c *Client) ClearAllProcessLogs() ([]interface{}, error) {
-	return c.CallAsInterfaceArray(clearAllProcessLogs)
+func (c *Client) ClearAllProcessLogs() (ProcessResults, error) {
+	return c.ClearAllProcessLogsContext(context.Background())
+}
+
+func (c *Client) ClearAllProcessLogsContext(ctx context.Context) (ProcessResults, error) {
+	return c.HandleProcessResultsContext(ctx, clearAllProcessLogs)
 }
 
 type tailFn func(string, int64, int) (string, int64, bool, error)
@@ -73,6 +98,16 @@ type tailFn func(string, int64, int) (string, int64, bool, error)
 //	@param bufferSize: if 0 is passed in, will use default 5120
 //	@param processFn: if nil is passed in, will use default TailProcessStdoutLog
 func (c *Client) TailFProcessLog(name string, bufferSize int, processFn tailFn) {
+	if err := c.TailFProcessLogContext(context.Background(), name, bufferSize, processFn); err != nil {
+		panic(err)
+	}
+}
+
+// TailFProcessLogContext works like TailFProcessLog, but returns when ctx is done
+// or the tail call fails instead of panicking.
+//
+//	@param processFn: if nil is passed in, will use TailProcessStdoutLogContext bound to ctx
+func (c *Client) TailFProcessLogContext(ctx context.Context, name string, bufferSize int, processFn tailFn) error {
 	var err error
 
 	const readIntervalMs = 100
@@ -82,16 +117,22 @@ func (c *Client) TailFProcessLog(name string, bufferSize int, processFn tailFn)
 	}
 
 	if processFn == nil {
-		processFn = c.TailProcessStdoutLog
+		processFn = func(name string, offset int64, length int) (string, int64, bool, error) {
+			return c.TailProcessStdoutLogContext(ctx, name, offset, length)
+		}
 	}
 
 	got, offset, overflow := "", int64(0), false
 	lastOffset := offset
 
 	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		got, offset, overflow, err = processFn(name, lastOffset, bufferSize)
 		if err != nil {
-			panic(err)
+			return err
 		}
 
 		if offset > lastOffset {
@@ -115,7 +156,11 @@ func (c *Client) TailFProcessLog(name string, bufferSize int, processFn tailFn)
 				}
 			}
 		} else {
-			time.Sleep(time.Millisecond * time.Duration(readIntervalMs))
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Millisecond * time.Duration(readIntervalMs)):
+			}
 		}
 	}
 }
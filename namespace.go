@@ -0,0 +1,99 @@
+package supervisord
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// Namespace is an rpc namespace registered by a supervisord plugin through [rpcinterface:x],
+// such as twiddler or cache. Plugin clients are built on top of it.
+//
+// Example:
+//
+//	cache := c.Namespace("cache")
+//
+//	var keys []string
+//	err := cache.Call(ctx, "getKeys", &keys)
+type Namespace struct {
+	c    *Client
+	name string
+}
+
+// WithNamespace registers plugin namespaces, so calls to "name.method" are not sent to supervisor.*.
+func WithNamespace(names ...string) ClientOptions {
+	return func(o *Client) {
+		for _, name := range names {
+			o.registerNamespace(name)
+		}
+	}
+}
+
+// Namespace registers name when needed and returns a handle to call its methods.
+func (c *Client) Namespace(name string) *Namespace {
+	c.registerNamespace(name)
+
+	return &Namespace{c: c, name: name}
+}
+
+// Namespaces returns the registered plugin namespaces, sorted.
+func (c *Client) Namespaces() []string {
+	c.nsMu.RLock()
+	defer c.nsMu.RUnlock()
+
+	names := make([]string, 0, len(c.namespaces))
+	for name := range c.namespaces {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func (c *Client) registerNamespace(name string) {
+	c.nsMu.Lock()
+	defer c.nsMu.Unlock()
+
+	if c.namespaces == nil {
+		c.namespaces = make(map[string]bool)
+	}
+
+	c.namespaces[strings.TrimSuffix(name, ".")] = true
+}
+
+// hasNamespace reports whether cmd is already qualified by system. or a registered namespace.
+func (c *Client) hasNamespace(cmd string) bool {
+	if strings.HasPrefix(cmd, _prefixSystem) || strings.HasPrefix(cmd, _prefix) {
+		return true
+	}
+
+	ns, _, found := strings.Cut(cmd, ".")
+	if !found {
+		return false
+	}
+
+	c.nsMu.RLock()
+	defer c.nsMu.RUnlock()
+
+	return c.namespaces[ns]
+}
+
+func (ns *Namespace) Name() string {
+	return ns.name
+}
+
+// Method returns the qualified CMD of method in the namespace, usable with Client.CallAs* and BatchCall.
+func (ns *Namespace) Method(method string) CMD {
+	return CMD(ns.name + "." + method)
+}
+
+// Call sends method of the namespace and decodes the response into reply.
+func (ns *Namespace) Call(ctx context.Context, method string, reply any, args ...any) error {
+	return ns.c.callContext(ctx, ns.Method(method), ns.c.refineArgs(args...), reply)
+}
+
+// CallAsBool calls a method which returns true on success, like most supervisor methods.
+func (ns *Namespace) CallAsBool(ctx context.Context, method string, args ...any) error {
+	return ns.c.CallAsBoolContext(ctx, ns.Method(method), args...)
+}
@@ -0,0 +1,83 @@
+package supervisord
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// PriorityLevels returns the processes selected by sel in the order startAllProcesses would
+// start them: ascending group_prio, then ascending process_prio. Processes sharing both
+// priorities form one level.
+func (c *Client) PriorityLevels(ctx context.Context, sel *Selector) ([][]string, error) {
+	selected, err := c.Select(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := c.GetAllConfigInfoContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(selected))
+	for _, pi := range selected {
+		names[pi.FullName()] = true
+	}
+
+	return priorityLevels(configs, names), nil
+}
+
+// StartOrdered starts the selected processes by ascending priority, a level at a time, waiting
+// for each level to be RUNNING before starting the next one. Processes already running are fine.
+func (c *Client) StartOrdered(ctx context.Context, sel *Selector, opts ...BulkOptions) (BulkResult, error) {
+	levels, err := c.PriorityLevels(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.runLevels(ctx, levels, c.startRunning, opts...)
+}
+
+// StopOrdered stops the selected processes by descending priority, like stopAllProcesses.
+func (c *Client) StopOrdered(ctx context.Context, sel *Selector, opts ...BulkOptions) (BulkResult, error) {
+	levels, err := c.PriorityLevels(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(levels)
+
+	return c.runLevels(ctx, levels, c.stopStopped, opts...)
+}
+
+// priorityLevels sorts the configs of names by (group_prio, process_prio) and groups equal priorities.
+func priorityLevels(configs []ProcessConfig, names map[string]bool) [][]string {
+	var picked []ProcessConfig
+
+	for _, pc := range configs {
+		if names[pc.FullName()] {
+			picked = append(picked, pc)
+		}
+	}
+
+	slices.SortFunc(picked, func(a, b ProcessConfig) int {
+		return cmp.Or(
+			cmp.Compare(a.GroupPrio, b.GroupPrio),
+			cmp.Compare(a.ProcessPrio, b.ProcessPrio),
+			cmp.Compare(a.FullName(), b.FullName()),
+		)
+	})
+
+	var levels [][]string
+
+	for i, pc := range picked {
+		if i == 0 || pc.GroupPrio != picked[i-1].GroupPrio || pc.ProcessPrio != picked[i-1].ProcessPrio {
+			levels = append(levels, nil)
+		}
+
+		levels[len(levels)-1] = append(levels[len(levels)-1], pc.FullName())
+	}
+
+	return levels
+}
@@ -0,0 +1,33 @@
+package supervisord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PrioritySuite struct {
+	suite.Suite
+}
+
+func TestPriority(t *testing.T) {
+	suite.Run(t, new(PrioritySuite))
+}
+
+func (s *PrioritySuite) Test_01_levels() {
+	configs := []ProcessConfig{
+		{Group: "web", Name: "web_00", GroupPrio: 999, ProcessPrio: 999},
+		{Group: "web", Name: "web_01", GroupPrio: 999, ProcessPrio: 999},
+		{Group: "redis", Name: "redis", GroupPrio: 10, ProcessPrio: 10},
+		{Group: "api", Name: "api", GroupPrio: 100, ProcessPrio: 100},
+		{Group: "cron", Name: "cron", GroupPrio: 1, ProcessPrio: 1},
+	}
+
+	names := map[string]bool{"web:web_00": true, "web:web_01": true, "redis:redis": true, "api:api": true}
+
+	s.Equal([][]string{
+		{"redis:redis"},
+		{"api:api"},
+		{"web:web_00", "web:web_01"},
+	}, priorityLevels(configs, names))
+}
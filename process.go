@@ -1,24 +1,26 @@
 package supervisord
 
 import (
+	"context"
+	"errors"
 	"syscall"
 )
 
 type ProcessState int
 
 type ProcessInfo struct {
-	Name          string       `xmlrpc:"name"`           // Name of the process
-	Group         string       `xmlrpc:"group"`          // Name of the process’ group
-	Start         int          `xmlrpc:"start"`          // UNIX timestamp of when the process was started
-	Stop          int          `xmlrpc:"stop"`           // UNIX timestamp of when the process last ended, or 0 if the process has never been stopped
-	Now           int          `xmlrpc:"now"`            // UNIX timestamp of the current time, which can be used to calculate process up-time.
-	State         ProcessState `xmlrpc:"state"`          // State code, see ProcessState.
-	StateName     StateName    `xmlrpc:"statename"`      // String description of state
-	SpawnErr      string       `xmlrpc:"spawnerr"`       // Description of error that occurred during spawn, or empty string if none
-	ExitStatus    int          `xmlrpc:"exitstatus"`     // Exit status (errorlevel) of process, or 0 if the process is still running
-	StdoutLogfile string       `xmlrpc:"stdout_logfile"` // Absolute path and filename to the STDOUT logfile
-	StderrLogfile string       `xmlrpc:"stderr_logfile"` // Absolute path and filename to the STDOUT logfile
-	Pid           int          `xmlrpc:"pid"`            // UNIX process ID (PID) of the process, or 0 if the process is not running
+	Name          string       `xmlrpc:"name" json:"name"`                     // Name of the process
+	Group         string       `xmlrpc:"group" json:"group"`                   // Name of the process’ group
+	Start         int          `xmlrpc:"start" json:"start"`                   // UNIX timestamp of when the process was started
+	Stop          int          `xmlrpc:"stop" json:"stop"`                     // UNIX timestamp of when the process last ended, or 0 if the process has never been stopped
+	Now           int          `xmlrpc:"now" json:"now"`                       // UNIX timestamp of the current time, which can be used to calculate process up-time.
+	State         ProcessState `xmlrpc:"state" json:"state"`                   // State code, see ProcessState.
+	StateName     StateName    `xmlrpc:"statename" json:"statename"`           // String description of state
+	SpawnErr      string       `xmlrpc:"spawnerr" json:"spawnerr"`             // Description of error that occurred during spawn, or empty string if none
+	ExitStatus    int          `xmlrpc:"exitstatus" json:"exitstatus"`         // Exit status (errorlevel) of process, or 0 if the process is still running
+	StdoutLogfile string       `xmlrpc:"stdout_logfile" json:"stdout_logfile"` // Absolute path and filename to the STDOUT logfile
+	StderrLogfile string       `xmlrpc:"stderr_logfile" json:"stderr_logfile"` // Absolute path and filename to the STDOUT logfile
+	Pid           int          `xmlrpc:"pid" json:"pid"`                       // UNIX process ID (PID) of the process, or 0 if the process is not running
 }
 
 const (
@@ -32,23 +34,118 @@ const (
 	StateUnknown  ProcessState = 1000 // The process is in an unknown state (supervisord programming error)
 )
 
+var processStateNames = map[ProcessState]string{
+	StateStopped:  "STOPPED",
+	StateStarting: "STARTING",
+	StateRunning:  "RUNNING",
+	StateBackoff:  "BACKOFF",
+	StateStopping: "STOPPING",
+	StateExited:   "EXITED",
+	StateFatal:    "FATAL",
+	StateUnknown:  "UNKNOWN",
+}
+
+// String returns the statename supervisord uses for the state.
+func (s ProcessState) String() string {
+	if name, ok := processStateNames[s]; ok {
+		return name
+	}
+
+	return "UNKNOWN"
+}
+
+// ProcessResult is the per process outcome supervisord returns from group and all-process operations.
+type ProcessResult struct {
+	Name        string    `xmlrpc:"name"`        // Name of the process
+	Group       string    `xmlrpc:"group"`       // Name of the process’ group
+	Status      FaultCode `xmlrpc:"status"`      // FaultSuccess, or the fault code of the failure
+	Description string    `xmlrpc:"description"` // "OK", or a description of the failure
+}
+
+// FullName returns the "group:name" form supervisord accepts for the process.
+func (r ProcessResult) FullName() string {
+	return r.Group + ":" + r.Name
+}
+
+// Err returns nil when the operation succeeded for the process, otherwise a *Fault.
+// Description is the faultString supervisord would send for the process, e.g. "SPAWN_ERROR: web:web_01".
+func (r ProcessResult) Err() error {
+	if r.Status == FaultSuccess {
+		return nil
+	}
+
+	f := NewFault(int(r.Status), r.Description)
+	f.Name = r.FullName()
+
+	return f
+}
+
+type ProcessResults []ProcessResult
+
+// Failed returns the results whose status is not FaultSuccess.
+func (rs ProcessResults) Failed() ProcessResults {
+	var failed ProcessResults
+
+	for _, r := range rs {
+		if r.Status != FaultSuccess {
+			failed = append(failed, r)
+		}
+	}
+
+	return failed
+}
+
+// Err joins the errors of all failed results, nil when every process succeeded.
+func (rs ProcessResults) Err() error {
+	var errs []error
+	for _, r := range rs.Failed() {
+		errs = append(errs, r.Err())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Client) HandleProcessResults(name CMD, args ...any) (ProcessResults, error) {
+	return c.HandleProcessResultsContext(context.Background(), name, args...)
+}
+
+func (c *Client) HandleProcessResultsContext(ctx context.Context, name CMD, args ...any) (ProcessResults, error) {
+	var results ProcessResults
+
+	err := c.callContext(ctx, name, args, &results)
+
+	return results, err
+}
+
 func (c *Client) HandleAllProcesses(name CMD, args ...any) ([]ProcessInfo, error) {
+	return c.HandleAllProcessesContext(context.Background(), name, args...)
+}
+
+func (c *Client) HandleAllProcessesContext(ctx context.Context, name CMD, args ...any) ([]ProcessInfo, error) {
 	var piArr []ProcessInfo
 
-	err := c.call(name, args, &piArr)
+	err := c.callContext(ctx, name, args, &piArr)
 
 	return piArr, err
 }
 
 func (c *Client) GetProcessInfo(name string) (*ProcessInfo, error) {
+	return c.GetProcessInfoContext(context.Background(), name)
+}
+
+func (c *Client) GetProcessInfoContext(ctx context.Context, name string) (*ProcessInfo, error) {
 	var processinfo ProcessInfo
-	err := c.call(getProcessInfo, name, &processinfo)
+	err := c.callContext(ctx, getProcessInfo, name, &processinfo)
 
 	return &processinfo, err
 }
 
 func (c *Client) GetAllProcessInfo() ([]ProcessInfo, error) {
-	return c.HandleAllProcesses(getAllProcessInfo)
+	return c.GetAllProcessInfoContext(context.Background())
+}
+
+func (c *Client) GetAllProcessInfoContext(ctx context.Context) ([]ProcessInfo, error) {
+	return c.HandleAllProcessesContext(ctx, getAllProcessInfo)
 }
 
 type ProcessConfig struct {
@@ -84,64 +181,124 @@ type ProcessConfig struct {
 }
 
 func (c *Client) GetAllConfigInfo() ([]ProcessConfig, error) {
+	return c.GetAllConfigInfoContext(context.Background())
+}
+
+func (c *Client) GetAllConfigInfoContext(ctx context.Context) ([]ProcessConfig, error) {
 	var piArr []ProcessConfig
-	err := c.call(getAllConfigInfo, nil, &piArr)
+	err := c.callContext(ctx, getAllConfigInfo, nil, &piArr)
 
 	return piArr, err
 }
 
 func (c *Client) StartProcess(name string, wait bool) error {
-	return c.CallAsBool(startProcess, name, wait)
+	return c.StartProcessContext(context.Background(), name, wait)
+}
+
+func (c *Client) StartProcessContext(ctx context.Context, name string, wait bool) error {
+	return c.CallAsBoolContext(ctx, startProcess, name, wait)
+}
+
+func (c *Client) StartAllProcesses(wait bool) (ProcessResults, error) {
+	return c.StartAllProcessesContext(context.Background(), wait)
+}
+
+func (c *Client) StartAllProcessesContext(ctx context.Context, wait bool) (ProcessResults, error) {
+	return c.HandleProcessResultsContext(ctx, startAllProcesses, wait)
 }
 
-func (c *Client) StartAllProcesses(wait bool) ([]ProcessInfo, error) {
-	return c.HandleAllProcesses(startAllProcesses, wait)
+func (c *Client) StartProcessGroup(name string, wait bool) (ProcessResults, error) {
+	return c.StartProcessGroupContext(context.Background(), name, wait)
 }
 
-func (c *Client) StartProcessGroup(name string, wait bool) ([]ProcessInfo, error) {
-	return c.HandleAllProcesses(startProcessGroup, name, wait)
+func (c *Client) StartProcessGroupContext(ctx context.Context, name string, wait bool) (ProcessResults, error) {
+	return c.HandleProcessResultsContext(ctx, startProcessGroup, name, wait)
 }
 
 func (c *Client) StopProcess(name string, wait bool) error {
-	return c.CallAsBool(stopProcess, name, wait)
+	return c.StopProcessContext(context.Background(), name, wait)
+}
+
+func (c *Client) StopProcessContext(ctx context.Context, name string, wait bool) error {
+	return c.CallAsBoolContext(ctx, stopProcess, name, wait)
+}
+
+func (c *Client) StopProcessGroup(name string, wait bool) (ProcessResults, error) {
+	return c.StopProcessGroupContext(context.Background(), name, wait)
+}
+
+func (c *Client) StopProcessGroupContext(ctx context.Context, name string, wait bool) (ProcessResults, error) {
+	return c.HandleProcessResultsContext(ctx, stopProcessGroup, name, wait)
 }
 
-func (c *Client) StopProcessGroup(name string, wait bool) ([]ProcessInfo, error) {
-	return c.HandleAllProcesses(stopProcessGroup, name, wait)
+func (c *Client) StopAllProcesses(wait bool) (ProcessResults, error) {
+	return c.StopAllProcessesContext(context.Background(), wait)
 }
 
-func (c *Client) StopAllProcesses(wait bool) ([]ProcessInfo, error) {
-	return c.HandleAllProcesses(stopAllProcesses, wait)
+func (c *Client) StopAllProcessesContext(ctx context.Context, wait bool) (ProcessResults, error) {
+	return c.HandleProcessResultsContext(ctx, stopAllProcesses, wait)
 }
 
 func (c *Client) SignalProcess(name string, signal syscall.Signal) error {
-	return c.CallAsBool(signalProcess, name, int(signal))
+	return c.SignalProcessContext(context.Background(), name, signal)
 }
 
-func (c *Client) SignalProcessGroup(signal syscall.Signal) ([]ProcessInfo, error) {
-	return c.HandleAllProcesses(signalProcessGroup, int(signal))
+func (c *Client) SignalProcessContext(ctx context.Context, name string, signal syscall.Signal) error {
+	return c.CallAsBoolContext(ctx, signalProcess, name, int(signal))
 }
 
-func (c *Client) SignalAllProcesses(signal syscall.Signal) ([]ProcessInfo, error) {
-	return c.HandleAllProcesses(signalAllProcesses, int(signal))
+func (c *Client) SignalProcessGroup(name string, signal syscall.Signal) (ProcessResults, error) {
+	return c.SignalProcessGroupContext(context.Background(), name, signal)
+}
+
+func (c *Client) SignalProcessGroupContext(ctx context.Context, name string, signal syscall.Signal) (ProcessResults, error) {
+	return c.HandleProcessResultsContext(ctx, signalProcessGroup, name, int(signal))
+}
+
+func (c *Client) SignalAllProcesses(signal syscall.Signal) (ProcessResults, error) {
+	return c.SignalAllProcessesContext(context.Background(), signal)
+}
+
+func (c *Client) SignalAllProcessesContext(ctx context.Context, signal syscall.Signal) (ProcessResults, error) {
+	return c.HandleProcessResultsContext(ctx, signalAllProcesses, int(signal))
 }
 
 func (c *Client) SendProcessStdin(name string, chars string) error {
-	return c.CallAsBool(sendProcessStdin, name, chars)
+	return c.SendProcessStdinContext(context.Background(), name, chars)
+}
+
+func (c *Client) SendProcessStdinContext(ctx context.Context, name string, chars string) error {
+	return c.CallAsBoolContext(ctx, sendProcessStdin, name, chars)
 }
 
 func (c *Client) SendRemoteCommEvent(eventHeader string, eventBody string) error {
-	return c.CallAsBool(sendRemoteCommEvent, eventHeader, eventBody)
+	return c.SendRemoteCommEventContext(context.Background(), eventHeader, eventBody)
+}
+
+func (c *Client) SendRemoteCommEventContext(ctx context.Context, eventHeader string, eventBody string) error {
+	return c.CallAsBoolContext(ctx, sendRemoteCommEvent, eventHeader, eventBody)
 }
 
 func (c *Client) ReloadConfig() ([]interface{}, error) {
-	return c.CallAsInterfaceArray(reloadConfig)
+	return c.ReloadConfigContext(context.Background())
+}
+
+func (c *Client) ReloadConfigContext(ctx context.Context) ([]interface{}, error) {
+	return c.CallAsInterfaceArrayContext(ctx, reloadConfig)
 }
 
 func (c *Client) AddProcessGroup(name string) error {
-	return c.CallAsBool(addProcessGroup, name)
+	return c.AddProcessGroupContext(context.Background(), name)
+}
+
+func (c *Client) AddProcessGroupContext(ctx context.Context, name string) error {
+	return c.CallAsBoolContext(ctx, addProcessGroup, name)
 }
 
 func (c *Client) RemoveProcessGroup(name string) error {
-	return c.CallAsBool(removeProcessGroup, name)
+	return c.RemoveProcessGroupContext(context.Background(), name)
+}
+
+func (c *Client) RemoveProcessGroupContext(ctx context.Context, name string) error {
+	return c.CallAsBoolContext(ctx, removeProcessGroup, name)
 }
@@ -0,0 +1,181 @@
+package supervisord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type RestartPhase string
+
+const (
+	RestartPhaseStop  RestartPhase = "stop"
+	RestartPhaseStart RestartPhase = "start"
+)
+
+// RestartError tells which phase of a restart failed, Err keeps the fault for errors.Is.
+type RestartError struct {
+	Name  string // "group:name" of a process, or the group name for group restarts
+	Phase RestartPhase
+	Err   error
+
+	// Results is the per process outcome of the failed phase of a group or all-process restart,
+	// empty when the call itself failed.
+	Results ProcessResults
+}
+
+func (e *RestartError) Error() string {
+	return fmt.Sprintf("cannot restart %s, %s failed: %v", e.Name, e.Phase, e.Err)
+}
+
+func (e *RestartError) Unwrap() error {
+	return e.Err
+}
+
+// RestartResult reports a restarted process, OldPID is 0 when it was not running.
+type RestartResult struct {
+	Name     string
+	OldPID   int
+	NewPID   int
+	Duration time.Duration
+}
+
+func (c *Client) RestartProcess(name string) (*RestartResult, error) {
+	return c.RestartProcessContext(context.Background(), name)
+}
+
+// RestartProcessContext stops the process waiting for it to exit, tolerating it was not running,
+// then starts it waiting for it to be running, like supervisorctl restart.
+func (c *Client) RestartProcessContext(ctx context.Context, name string) (*RestartResult, error) {
+	name = FullName(name)
+	start := time.Now()
+
+	before, err := c.GetProcessInfoContext(ctx, name)
+	if err != nil {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStop, Err: err}
+	}
+
+	if err := c.StopProcessContext(ctx, name, true); err != nil && !errors.Is(err, ErrNotRunning) {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStop, Err: err}
+	}
+
+	if err := c.StartProcessContext(ctx, name, true); err != nil {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStart, Err: err}
+	}
+
+	after, err := c.GetProcessInfoContext(ctx, name)
+	if err != nil {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStart, Err: err}
+	}
+
+	return &RestartResult{Name: name, OldPID: before.Pid, NewPID: after.Pid, Duration: time.Since(start)}, nil
+}
+
+func (c *Client) RestartProcessGroup(name string) ([]RestartResult, error) {
+	return c.RestartProcessGroupContext(context.Background(), name)
+}
+
+// RestartProcessGroupContext stops and starts all members of the group, waiting for both.
+// When some members fail, the members restarted are returned along with a *RestartError
+// whose Results tell the outcome of each member in the failed phase.
+func (c *Client) RestartProcessGroupContext(ctx context.Context, name string) ([]RestartResult, error) {
+	return c.restartSelected(ctx, name, InGroup(name),
+		func(ctx context.Context) (ProcessResults, error) {
+			return c.StopProcessGroupContext(ctx, name, true)
+		},
+		func(ctx context.Context) (ProcessResults, error) {
+			return c.StartProcessGroupContext(ctx, name, true)
+		})
+}
+
+func (c *Client) RestartAll() ([]RestartResult, error) {
+	return c.RestartAllContext(context.Background())
+}
+
+// RestartAllContext stops and starts all processes, waiting for both.
+// Partial failures are reported like in RestartProcessGroupContext.
+func (c *Client) RestartAllContext(ctx context.Context) ([]RestartResult, error) {
+	return c.restartSelected(ctx, "*", All(),
+		func(ctx context.Context) (ProcessResults, error) {
+			return c.StopAllProcessesContext(ctx, true)
+		},
+		func(ctx context.Context) (ProcessResults, error) {
+			return c.StartAllProcessesContext(ctx, true)
+		})
+}
+
+func (c *Client) restartSelected(
+	ctx context.Context, name string, sel *Selector, stop, start func(context.Context) (ProcessResults, error),
+) ([]RestartResult, error) {
+	begin := time.Now()
+
+	before, err := c.Select(ctx, sel)
+	if err != nil {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStop, Err: err}
+	}
+
+	stopped, err := stop(ctx)
+	if err != nil {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStop, Err: err}
+	}
+
+	if err := toleratingNotRunning(stopped).Err(); err != nil {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStop, Err: err, Results: stopped}
+	}
+
+	started, err := start(ctx)
+	if err != nil {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStart, Err: err}
+	}
+
+	after, err := c.Select(ctx, sel)
+	if err != nil {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStart, Err: err, Results: started}
+	}
+
+	failed := make(map[string]bool)
+	for _, r := range started.Failed() {
+		failed[r.FullName()] = true
+	}
+
+	pids := make(map[string]int, len(after))
+	for _, pi := range after {
+		pids[pi.FullName()] = pi.Pid
+	}
+
+	took := time.Since(begin)
+	results := make([]RestartResult, 0, len(before))
+
+	for _, pi := range before {
+		if failed[pi.FullName()] {
+			continue
+		}
+
+		results = append(results, RestartResult{
+			Name:     pi.FullName(),
+			OldPID:   pi.Pid,
+			NewPID:   pids[pi.FullName()],
+			Duration: took,
+		})
+	}
+
+	if err := started.Err(); err != nil {
+		return results, &RestartError{Name: name, Phase: RestartPhaseStart, Err: err, Results: started}
+	}
+
+	return results, nil
+}
+
+// toleratingNotRunning drops NOT_RUNNING failures, stopping a stopped process is fine for a restart.
+func toleratingNotRunning(results ProcessResults) ProcessResults {
+	kept := make(ProcessResults, 0, len(results))
+
+	for _, r := range results {
+		if r.Status != FaultNotRunning {
+			kept = append(kept, r)
+		}
+	}
+
+	return kept
+}
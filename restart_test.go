@@ -0,0 +1,81 @@
+package supervisord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RestartSuite struct {
+	suite.Suite
+	fake *fakeSupervisor
+	c    *Client
+}
+
+func TestRestart(t *testing.T) {
+	suite.Run(t, new(RestartSuite))
+}
+
+func (s *RestartSuite) SetupTest() {
+	broken := newFakeProcess("web", "web_01", StateRunning)
+	broken.failStart = true
+
+	s.fake = newFakeSupervisor(s.T(),
+		newFakeProcess("web", "web_00", StateRunning),
+		broken,
+		newFakeProcess("web", "web_02", StateStopped),
+	)
+	s.c = s.fake.client()
+}
+
+func (s *RestartSuite) Test_01_process() {
+	old := s.fake.info("web:web_00").Pid
+
+	r, err := s.c.RestartProcess("web:web_00")
+	s.Nil(err)
+	s.Equal(old, r.OldPID)
+	s.NotEqual(old, r.NewPID)
+	s.Equal(StateRunning, s.fake.info("web:web_00").State)
+
+	_, err = s.c.RestartProcess("web:web_01")
+
+	var re *RestartError
+	s.ErrorAs(err, &re)
+	s.Equal(RestartPhaseStart, re.Phase)
+	s.ErrorIs(err, ErrSpawnError)
+}
+
+func (s *RestartSuite) Test_02_groupPartial() {
+	results, err := s.c.RestartProcessGroupContext(context.Background(), "web")
+	s.ErrorIs(err, ErrSpawnError)
+
+	var re *RestartError
+	s.ErrorAs(err, &re)
+	s.Equal(RestartPhaseStart, re.Phase)
+	s.Len(re.Results, 3)
+	s.Equal([]string{"web:web_01"}, resultNames(re.Results.Failed()))
+
+	var names []string
+	for _, r := range results {
+		names = append(names, r.Name)
+		s.NotZero(r.NewPID)
+	}
+
+	s.ElementsMatch([]string{"web:web_00", "web:web_02"}, names)
+}
+
+func (s *RestartSuite) Test_03_badGroup() {
+	results, err := s.c.RestartProcessGroup("nope")
+	s.Nil(results)
+	s.ErrorIs(err, ErrBadName)
+}
+
+func resultNames(results ProcessResults) []string {
+	var names []string
+	for _, r := range results {
+		names = append(names, r.FullName())
+	}
+
+	return names
+}
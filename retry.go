@@ -0,0 +1,179 @@
+package supervisord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy retries calls failing with transient errors, such as connection refused
+// while supervisord restarts or SHUTDOWN_STATE faults.
+//
+// Only idempotent read calls are retried unless a method is listed in Retryable,
+// or the call is made with a context returned by RetryableContext.
+type RetryPolicy struct {
+	MaxAttempts    int           // total attempts including the first one, values below 2 disable retrying
+	InitialBackoff time.Duration // backoff before the second attempt
+	MaxBackoff     time.Duration // upper bound of the backoff, 0 means unbounded
+	Multiplier     float64       // growth factor of the backoff between attempts, values below 1 keep it constant
+	Jitter         float64       // fraction (0-1) of the backoff randomized to spread retries of concurrent callers
+	Retryable      []CMD         // mutating methods which are safe to retry for the caller, e.g. startProcess
+}
+
+// DefaultRetryPolicy retries idempotent calls 3 times over roughly 1.4s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// idempotentMethods are retried by default, they never change supervisord state.
+var idempotentMethods = []CMD{
+	getAPIVersion,
+	getVersion,
+	getSupervisorVersion,
+	getIdentification,
+	getState,
+	getPID,
+	readLog,
+	readMainLog,
+	getProcessInfo,
+	getAllProcessInfo,
+	getAllConfigInfo,
+	readProcessLog,
+	readProcessStdoutLog,
+	readProcessStderrLog,
+	tailProcessLog,
+	tailProcessStdoutLog,
+	tailProcessStderrLog,
+	listMethods,
+	methodHelp,
+	methodSignature,
+}
+
+type retryableKey struct{}
+
+// RetryableContext marks every call made with the returned context as safe to retry.
+func RetryableContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryableKey{}, true)
+}
+
+func WithRetry(p RetryPolicy) ClientOptions {
+	return func(o *Client) {
+		o.retry = &p
+	}
+}
+
+// HTTPStatusError is returned when supervisord or a proxy in front of it answers with a non 2xx status.
+type HTTPStatusError struct {
+	StatusCode int
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("request error: bad status code - %d", e.StatusCode)
+}
+
+// invokeWithRetry calls invoke and retries transient failures as allowed by the retry policy.
+func (c *Client) invokeWithRetry(ctx context.Context, method string, args any, reply any) error {
+	p := c.retry
+	if p == nil || p.MaxAttempts < 2 || !c.retryable(ctx, method) {
+		return c.invoke(ctx, method, args, reply)
+	}
+
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		// faults are typed here already, so isTransient can match SHUTDOWN_STATE
+		err = asFault(c.invoke(ctx, method, args, reply))
+		if err == nil || attempt >= p.MaxAttempts || !isTransient(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(p.backoff(attempt)):
+		}
+	}
+}
+
+func (c *Client) retryable(ctx context.Context, method string) bool {
+	if marked, _ := ctx.Value(retryableKey{}).(bool); marked {
+		return true
+	}
+
+	for _, cmd := range idempotentMethods {
+		if c.refineCmd(cmd) == method {
+			return true
+		}
+	}
+
+	for _, cmd := range c.retry.Retryable {
+		if c.refineCmd(cmd) == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the delay after the given failed attempt, starting at 1.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff)
+
+	for i := 1; i < attempt && p.Multiplier > 1; i++ {
+		d *= p.Multiplier
+		if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+			d = float64(p.MaxBackoff)
+			break
+		}
+	}
+
+	if p.Jitter > 0 {
+		delta := d * min(p.Jitter, 1)
+		d = d - delta + rand.Float64()*2*delta //nolint:gosec
+	}
+
+	return time.Duration(d)
+}
+
+// isTransient reports whether err is likely to go away by itself, e.g. while supervisord restarts.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, ErrShutdownState) {
+		return true
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+
+		return false
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ENOENT) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
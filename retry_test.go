@@ -0,0 +1,118 @@
+package supervisord
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const shutdownResp = `<?xml version="1.0"?><methodResponse><fault><value><struct>
+<member><name>faultCode</name><value><int>6</int></value></member>
+<member><name>faultString</name><value><string>SHUTDOWN_STATE</string></value></member>
+</struct></value></fault></methodResponse>`
+
+type RetrySuite struct {
+	suite.Suite
+	srv      *httptest.Server
+	requests atomic.Int32
+	failures int32 // requests answered with failure before succeeding
+	status   int   // http status of the failures, 0 answers a SHUTDOWN_STATE fault
+	policy   RetryPolicy
+}
+
+func TestRetry(t *testing.T) {
+	suite.Run(t, new(RetrySuite))
+}
+
+func (s *RetrySuite) SetupTest() {
+	s.requests.Store(0)
+	s.failures = 2
+	s.status = 0
+	s.policy = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}
+
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if s.requests.Add(1) <= s.failures {
+			if s.status != 0 {
+				w.WriteHeader(s.status)
+				return
+			}
+
+			_, _ = w.Write([]byte(shutdownResp))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(boolTrueResp))
+	}))
+}
+
+func (s *RetrySuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *RetrySuite) client() *Client {
+	c, err := NewClient(s.srv.URL+"/RPC2", WithRetry(s.policy), WithCapabilityCheck(false))
+	s.Nil(err)
+
+	return c
+}
+
+func (s *RetrySuite) Test_01_shutdownState() {
+	var reply bool
+	s.Nil(s.client().callContext(context.Background(), getState, nil, &reply))
+	s.True(reply)
+	s.Equal(int32(3), s.requests.Load())
+}
+
+func (s *RetrySuite) Test_02_exhausted() {
+	s.failures = 5
+
+	var reply bool
+	err := s.client().callContext(context.Background(), getState, nil, &reply)
+	s.ErrorIs(err, ErrShutdownState)
+	s.Equal(int32(3), s.requests.Load())
+}
+
+func (s *RetrySuite) Test_03_notIdempotent() {
+	c := s.client()
+
+	s.ErrorIs(c.StopProcess("web", true), ErrShutdownState)
+	s.Equal(int32(1), s.requests.Load())
+
+	s.Nil(c.StopProcessContext(RetryableContext(context.Background()), "web", true))
+	s.Equal(int32(3), s.requests.Load())
+}
+
+func (s *RetrySuite) Test_04_httpStatus() {
+	s.status = http.StatusServiceUnavailable
+	s.Nil(s.client().ClearProcessLogsContext(RetryableContext(context.Background()), "web"))
+	s.Equal(int32(3), s.requests.Load())
+
+	s.requests.Store(0)
+	s.status = http.StatusUnauthorized
+
+	var statusErr *HTTPStatusError
+	s.ErrorAs(s.client().ClearProcessLogsContext(RetryableContext(context.Background()), "web"), &statusErr)
+	s.Equal(http.StatusUnauthorized, statusErr.StatusCode)
+	s.Equal(int32(1), s.requests.Load())
+}
+
+func (s *RetrySuite) Test_05_backoff() {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Multiplier: 2}
+	s.Equal(100*time.Millisecond, p.backoff(1))
+	s.Equal(200*time.Millisecond, p.backoff(2))
+	s.Equal(300*time.Millisecond, p.backoff(3))
+	s.Equal(300*time.Millisecond, p.backoff(10))
+
+	p.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		d := p.backoff(1)
+		s.GreaterOrEqual(d, 50*time.Millisecond)
+		s.LessOrEqual(d, 150*time.Millisecond)
+	}
+}
@@ -0,0 +1,210 @@
+package supervisord
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+const _defaultPollInterval = 500 * time.Millisecond
+
+var (
+	ErrUnexpectedState = errors.New("unexpected process state")
+	ErrHealthCheck     = errors.New("health check failed")
+)
+
+// StateError is returned when a process lands in a state it should not, such as BACKOFF or FATAL
+// while waiting for it to run. It matches ErrUnexpectedState with errors.Is.
+type StateError struct {
+	Name string
+	Info ProcessInfo
+}
+
+func (e *StateError) Error() string {
+	msg := fmt.Sprintf("%s is %s", e.Name, e.Info.State)
+	if e.Info.SpawnErr != "" {
+		msg += ": " + e.Info.SpawnErr
+	}
+
+	return msg
+}
+
+func (e *StateError) Unwrap() error {
+	return ErrUnexpectedState
+}
+
+// HealthCheck tells whether a restarted process is ready to take traffic.
+type HealthCheck func(ctx context.Context, pi ProcessInfo) error
+
+// RollingOptions configures RollingRestart, the zero value restarts one member at a time
+// and trusts the startsecs of each program.
+type RollingOptions struct {
+	BatchSize    int           // members restarted at once, 1 when 0
+	StartSecs    time.Duration // how long a member must stay RUNNING, its configured startsecs when 0
+	HealthCheck  HealthCheck   // optional, called once a member has been RUNNING for StartSecs
+	PollInterval time.Duration // interval of the state polling, 500ms when 0
+}
+
+// RollingRestart restarts the members of group a batch at a time. A batch is done when all its
+// members have been RUNNING for startsecs and passed the health check, the rollout is aborted
+// when a member lands in BACKOFF, FATAL or EXITED, or fails the health check. The other members
+// of the failing batch are left to finish their restart, later batches are not started.
+// The members restarted before the failure are returned along with the error.
+// An unknown group fails with BAD_NAME.
+func (c *Client) RollingRestart(ctx context.Context, group string, opts RollingOptions) ([]RestartResult, error) {
+	if opts.BatchSize < 1 {
+		opts.BatchSize = 1
+	}
+
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = _defaultPollInterval
+	}
+
+	members, err := c.Group(group).Processes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members) == 0 {
+		return nil, NewFault(int(FaultBadName), fmt.Sprintf("%s: %s", FaultBadName, group))
+	}
+
+	slices.SortFunc(members, func(a, b ProcessInfo) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	startsecs, err := c.startSecs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]RestartResult, 0, len(members))
+
+	for i := 0; i < len(members); i += opts.BatchSize {
+		batch := members[i:min(i+opts.BatchSize, len(members))]
+
+		names := make([]string, len(batch))
+		for j, pi := range batch {
+			names[j] = pi.FullName()
+		}
+
+		var mu sync.Mutex
+
+		restarted := make(map[string]*RestartResult, len(batch))
+
+		// the whole batch runs at once, so a failing member never leaves another one half restarted
+		res := c.Bulk(ctx, names, func(ctx context.Context, name string) error {
+			wait := opts.StartSecs
+			if wait == 0 {
+				wait = startsecs[name]
+			}
+
+			r, err := c.rollMember(ctx, name, wait, opts)
+			if r != nil {
+				mu.Lock()
+				restarted[name] = r
+				mu.Unlock()
+			}
+
+			return err
+		}, WithConcurrency(len(batch)))
+
+		for _, name := range names {
+			if r := restarted[name]; r != nil {
+				results = append(results, *r)
+			}
+		}
+
+		if err := res.Err(); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+// rollMember restarts one member and waits until it is stable and healthy.
+func (c *Client) rollMember(ctx context.Context, name string, startsecs time.Duration, opts RollingOptions) (*RestartResult, error) {
+	begin := time.Now()
+
+	before, err := c.GetProcessInfoContext(ctx, name)
+	if err != nil {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStop, Err: err}
+	}
+
+	if err := c.StopProcessContext(ctx, name, true); err != nil && !errors.Is(err, ErrNotRunning) {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStop, Err: err}
+	}
+
+	if err := c.StartProcessContext(ctx, name, false); err != nil {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStart, Err: err}
+	}
+
+	pi, err := c.waitStable(ctx, name, startsecs, opts.PollInterval)
+	if err != nil {
+		return nil, &RestartError{Name: name, Phase: RestartPhaseStart, Err: err}
+	}
+
+	if opts.HealthCheck != nil {
+		if err := opts.HealthCheck(ctx, *pi); err != nil {
+			return nil, &RestartError{Name: name, Phase: RestartPhaseStart, Err: fmt.Errorf("%w: %w", ErrHealthCheck, err)}
+		}
+	}
+
+	return &RestartResult{Name: name, OldPID: before.Pid, NewPID: pi.Pid, Duration: time.Since(begin)}, nil
+}
+
+// waitStable waits until the process is RUNNING and then checks it stays RUNNING under the same
+// pid for startsecs. supervisord reports RUNNING once startsecs passed since the spawn, so a member
+// crashing and brought back by autorestart would pass a check against its start time alone.
+func (c *Client) waitStable(ctx context.Context, name string, startsecs, interval time.Duration) (*ProcessInfo, error) {
+	pi, err := c.waitForState(ctx, name, interval, StateRunning, StateExited, StateStopped)
+	if err != nil {
+		return nil, err
+	}
+
+	if pi.State != StateRunning {
+		return nil, &StateError{Name: name, Info: *pi}
+	}
+
+	first, since := *pi, time.Now()
+
+	for {
+		left := startsecs - time.Since(since)
+		if left <= 0 {
+			return pi, nil
+		}
+
+		if err := sleepContext(ctx, min(interval, left)); err != nil {
+			return nil, err
+		}
+
+		pi, err = c.GetProcessInfoContext(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if pi.State != StateRunning || pi.Pid != first.Pid || pi.Start != first.Start {
+			return nil, &StateError{Name: name, Info: *pi}
+		}
+	}
+}
+
+// startSecs returns the configured startsecs of every process by "group:name".
+func (c *Client) startSecs(ctx context.Context) (map[string]time.Duration, error) {
+	configs, err := c.GetAllConfigInfoContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secs := make(map[string]time.Duration, len(configs))
+	for _, pc := range configs {
+		secs[pc.FullName()] = time.Duration(pc.Startsecs) * time.Second
+	}
+
+	return secs, nil
+}
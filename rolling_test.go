@@ -0,0 +1,145 @@
+package supervisord
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RollingSuite struct {
+	suite.Suite
+	fake *fakeSupervisor
+	c    *Client
+}
+
+func TestRolling(t *testing.T) {
+	suite.Run(t, new(RollingSuite))
+}
+
+func (s *RollingSuite) SetupTest() {
+	slow := newFakeProcess("web", "web_00", StateRunning)
+	slow.startDelay = 100 * time.Millisecond
+
+	s.fake = newFakeSupervisor(s.T(),
+		slow,
+		newFakeProcess("web", "web_01", StateRunning),
+		newFakeProcess("web", "web_02", StateRunning),
+		newFakeProcess("web", "web_03", StateRunning),
+		newFakeProcess("cron", "cron", StateRunning),
+	)
+	s.c = s.fake.client()
+}
+
+func (s *RollingSuite) names(results []RestartResult) []string {
+	var names []string
+	for _, r := range results {
+		names = append(names, r.Name)
+	}
+
+	return names
+}
+
+func (s *RollingSuite) Test_01_restart() {
+	var (
+		mu      sync.Mutex
+		checked []string
+	)
+
+	results, err := s.c.RollingRestart(context.Background(), "web", RollingOptions{
+		BatchSize: 3,
+		HealthCheck: func(_ context.Context, pi ProcessInfo) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			checked = append(checked, pi.FullName())
+
+			return nil
+		},
+	})
+	s.Nil(err)
+	s.Equal([]string{"web:web_00", "web:web_01", "web:web_02", "web:web_03"}, s.names(results))
+	s.Len(checked, 4)
+
+	for _, r := range results {
+		s.NotEqual(r.OldPID, r.NewPID)
+	}
+
+	s.Empty(s.fake.called("supervisor.stopProcess cron:cron"))
+}
+
+func (s *RollingSuite) Test_02_abortKeepsBatch() {
+	s.fake.set(func() { s.fake.find("web:web_01").failStart = true })
+
+	results, err := s.c.RollingRestart(context.Background(), "web", RollingOptions{BatchSize: 2, PollInterval: 10 * time.Millisecond})
+	s.ErrorIs(err, ErrUnexpectedState)
+
+	var re *RestartError
+	s.ErrorAs(err, &re)
+	s.Equal("web:web_01", re.Name)
+
+	// web_00 was still starting when web_01 failed, it finished its restart
+	s.Equal([]string{"web:web_00"}, s.names(results))
+	s.Equal(StateRunning, s.fake.info("web:web_00").State)
+	s.Empty(s.fake.called("supervisor.stopProcess web:web_02"))
+	s.Empty(s.fake.called("supervisor.stopProcess web:web_03"))
+}
+
+func (s *RollingSuite) Test_03_healthCheck() {
+	_, err := s.c.RollingRestart(context.Background(), "web", RollingOptions{
+		HealthCheck: func(context.Context, ProcessInfo) error {
+			return errors.New("503")
+		},
+	})
+	s.ErrorIs(err, ErrHealthCheck)
+	s.Empty(s.fake.called("supervisor.stopProcess web:web_01"))
+}
+
+func (s *RollingSuite) Test_04_badName() {
+	results, err := s.c.RollingRestart(context.Background(), "nope", RollingOptions{})
+	s.Nil(results)
+	s.ErrorIs(err, ErrBadName)
+
+	var f *Fault
+	s.ErrorAs(err, &f)
+	s.Equal("nope", f.Name)
+}
+
+func (s *RollingSuite) Test_05_restartedDuringStartSecs() {
+	opts := RollingOptions{StartSecs: 300 * time.Millisecond, PollInterval: 10 * time.Millisecond}
+
+	// RUNNING after its 100ms startDelay, then crashes and is brought back by autorestart
+	// while its startsecs run
+	time.AfterFunc(250*time.Millisecond, func() {
+		s.fake.set(func() {
+			p := s.fake.find("web:web_00")
+			s.fake.nextPID++
+			p.info.Pid = s.fake.nextPID
+		})
+	})
+
+	results, err := s.c.RollingRestart(context.Background(), "web", opts)
+	s.ErrorIs(err, ErrUnexpectedState)
+	s.Empty(results)
+
+	var se *StateError
+	s.ErrorAs(err, &se)
+	s.Equal("web:web_00", se.Name)
+	s.Equal(StateRunning, se.Info.State)
+}
+
+func (s *RollingSuite) Test_06_stableStartSecs() {
+	begin := time.Now()
+
+	results, err := s.c.RollingRestart(context.Background(), "cron", RollingOptions{
+		StartSecs:    50 * time.Millisecond,
+		PollInterval: 10 * time.Millisecond,
+	})
+	s.Nil(err)
+	s.Len(results, 1)
+	s.GreaterOrEqual(time.Since(begin), 50*time.Millisecond)
+	s.Greater(len(s.fake.called("supervisor.getProcessInfo cron:cron")), 2)
+}
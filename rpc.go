@@ -0,0 +1,44 @@
+package supervisord
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/kolo/xmlrpc"
+)
+
+// invoke sends a single xmlrpc request bound to ctx, cancelling ctx aborts the
+// in-flight http request.
+func (c *Client) invoke(ctx context.Context, method string, args any, reply any) error {
+	req, err := xmlrpc.NewRequest(c.rpcURL, method, args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return &HTTPStatusError{StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	res := xmlrpc.Response(body)
+	if err := res.Err(); err != nil {
+		return err
+	}
+
+	if reply == nil {
+		return nil
+	}
+
+	return res.Unmarshal(reply)
+}
@@ -0,0 +1,242 @@
+package supervisord
+
+import (
+	"context"
+	"errors"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+// Selector picks processes out of GetAllProcessInfo, like the name specs supervisorctl accepts.
+// A process is selected when it matches any of the names, groups, globs or regexps,
+// and is in one of the states set by InState.
+//
+// Example:
+//
+//	// stop all running workers
+//	results, err := c.Stop(ctx, Match("worker-*").InState(StateRunning))
+type Selector struct {
+	all     bool
+	names   []string
+	groups  []string
+	globs   []string
+	regexps []*regexp.Regexp
+	states  []ProcessState
+}
+
+// All selects every process.
+func All() *Selector {
+	return &Selector{all: true}
+}
+
+// Names selects processes by exact name, "group:name" or "name".
+func Names(names ...string) *Selector {
+	s := &Selector{}
+	for _, name := range names {
+		s.names = append(s.names, FullName(name))
+	}
+
+	return s
+}
+
+// InGroup selects all members of the groups.
+func InGroup(groups ...string) *Selector {
+	return &Selector{groups: groups}
+}
+
+// Match selects processes by supervisorctl style patterns: "group:*" selects a group,
+// patterns with glob meta characters (*?[) match the process name, or "group:name" when
+// they contain a colon, anything else is an exact name.
+func Match(patterns ...string) *Selector {
+	s := &Selector{}
+
+	for _, p := range patterns {
+		group, name, found := strings.Cut(p, ":")
+
+		switch {
+		case found && (name == "*" || name == ""):
+			s.groups = append(s.groups, group)
+		case strings.ContainsAny(p, "*?["):
+			s.globs = append(s.globs, p)
+		default:
+			s.names = append(s.names, FullName(p))
+		}
+	}
+
+	return s
+}
+
+// Regexp selects processes whose name or "group:name" matches re.
+func Regexp(re *regexp.Regexp) *Selector {
+	return &Selector{regexps: []*regexp.Regexp{re}}
+}
+
+// InState returns a copy of s which only selects processes in one of states.
+func (s *Selector) InState(states ...ProcessState) *Selector {
+	cp := *s
+	cp.states = append(slices.Clone(s.states), states...)
+
+	return &cp
+}
+
+// Matches reports whether pi is selected.
+func (s *Selector) Matches(pi ProcessInfo) bool {
+	if len(s.states) != 0 && !slices.Contains(s.states, pi.State) {
+		return false
+	}
+
+	return s.matchesName(pi)
+}
+
+func (s *Selector) matchesName(pi ProcessInfo) bool {
+	if s.all {
+		return true
+	}
+
+	full := pi.FullName()
+
+	if slices.Contains(s.names, full) || slices.Contains(s.groups, pi.Group) {
+		return true
+	}
+
+	for _, g := range s.globs {
+		target := pi.Name
+		if strings.Contains(g, ":") {
+			target = full
+		}
+
+		if ok, _ := path.Match(g, target); ok {
+			return true
+		}
+	}
+
+	for _, re := range s.regexps {
+		if re.MatchString(pi.Name) || re.MatchString(full) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns the selected processes of infos, in their original order.
+func (s *Selector) Filter(infos []ProcessInfo) []ProcessInfo {
+	var selected []ProcessInfo
+
+	for _, pi := range infos {
+		if s.Matches(pi) {
+			selected = append(selected, pi)
+		}
+	}
+
+	return selected
+}
+
+func (s *Selector) String() string {
+	var parts []string
+
+	if s.all {
+		parts = append(parts, "*")
+	}
+
+	parts = append(parts, s.names...)
+
+	for _, g := range s.groups {
+		parts = append(parts, g+":*")
+	}
+
+	parts = append(parts, s.globs...)
+
+	for _, re := range s.regexps {
+		parts = append(parts, "/"+re.String()+"/")
+	}
+
+	str := strings.Join(parts, ",")
+
+	if len(s.states) != 0 {
+		states := make([]string, len(s.states))
+		for i, st := range s.states {
+			states[i] = st.String()
+		}
+
+		str += " in " + strings.Join(states, ",")
+	}
+
+	return str
+}
+
+// Select returns the info of the processes selected by sel.
+func (c *Client) Select(ctx context.Context, sel *Selector) ([]ProcessInfo, error) {
+	all, err := c.GetAllProcessInfoContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sel.Filter(all), nil
+}
+
+// Start starts the selected processes concurrently, waiting for each to be running.
+// Faults are reported per process in the results, other errors such as a cancelled ctx are also returned.
+func (c *Client) Start(ctx context.Context, sel *Selector, opts ...BulkOptions) (ProcessResults, error) {
+	return c.eachSelected(ctx, sel, func(ctx context.Context, name string) error {
+		return c.StartProcessContext(ctx, name, true)
+	}, opts...)
+}
+
+// Stop stops the selected processes concurrently, waiting for each to be stopped.
+func (c *Client) Stop(ctx context.Context, sel *Selector, opts ...BulkOptions) (ProcessResults, error) {
+	return c.eachSelected(ctx, sel, func(ctx context.Context, name string) error {
+		return c.StopProcessContext(ctx, name, true)
+	}, opts...)
+}
+
+// Signal sends signal to the selected processes concurrently.
+func (c *Client) Signal(ctx context.Context, sel *Selector, signal syscall.Signal, opts ...BulkOptions) (ProcessResults, error) {
+	return c.eachSelected(ctx, sel, func(ctx context.Context, name string) error {
+		return c.SignalProcessContext(ctx, name, signal)
+	}, opts...)
+}
+
+func (c *Client) eachSelected(ctx context.Context, sel *Selector, fn func(ctx context.Context, name string) error, opts ...BulkOptions) (ProcessResults, error) {
+	infos, err := c.Select(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(infos))
+	for i, pi := range infos {
+		names[i] = pi.FullName()
+	}
+
+	res := c.Bulk(ctx, names, fn, opts...)
+
+	var errs []error
+
+	for _, name := range res.Failed() {
+		var f *Fault
+		if !errors.As(res[name], &f) {
+			errs = append(errs, res[name])
+		}
+	}
+
+	return res.Results(), errors.Join(errs...)
+}
+
+// newProcessResult builds the result of a single process call the way supervisord reports group operations.
+func newProcessResult(group, name string, err error) ProcessResult {
+	r := ProcessResult{Name: name, Group: group, Status: FaultSuccess, Description: "OK"}
+
+	if err == nil {
+		return r
+	}
+
+	f := &Fault{Code: FaultFailed, Description: err.Error()}
+	errors.As(err, &f)
+
+	r.Status, r.Description = f.Code, f.faultString()
+
+	return r
+}
@@ -0,0 +1,54 @@
+package supervisord
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SelectorSuite struct {
+	suite.Suite
+	infos []ProcessInfo
+}
+
+func TestSelector(t *testing.T) {
+	suite.Run(t, new(SelectorSuite))
+}
+
+func (s *SelectorSuite) SetupSuite() {
+	s.infos = []ProcessInfo{
+		{Group: "web", Name: "web_00", State: StateRunning},
+		{Group: "web", Name: "web_01", State: StateFatal},
+		{Group: "worker-a", Name: "worker-a", State: StateRunning},
+		{Group: "worker-b", Name: "worker-b", State: StateStopped},
+		{Group: "redis", Name: "redis", State: StateRunning},
+	}
+}
+
+func (s *SelectorSuite) names(sel *Selector) []string {
+	var names []string
+	for _, pi := range sel.Filter(s.infos) {
+		names = append(names, pi.FullName())
+	}
+
+	return names
+}
+
+func (s *SelectorSuite) Test_01_match() {
+	s.Equal([]string{"web:web_00", "web:web_01"}, s.names(Match("web:*")))
+	s.Equal([]string{"worker-a:worker-a", "worker-b:worker-b"}, s.names(Match("worker-*")))
+	s.Equal([]string{"redis:redis"}, s.names(Match("redis")))
+	s.Equal([]string{"web:web_01"}, s.names(Match("web:*_01")))
+	s.Nil(s.names(Match("nope")))
+}
+
+func (s *SelectorSuite) Test_02_state() {
+	s.Equal([]string{"worker-a:worker-a"}, s.names(Match("worker-*").InState(StateRunning)))
+	s.Equal([]string{"web:web_01"}, s.names(All().InState(StateFatal)))
+}
+
+func (s *SelectorSuite) Test_03_regexp() {
+	s.Equal([]string{"web:web_00", "web:web_01"}, s.names(Regexp(regexp.MustCompile(`^web_\d+$`))))
+	s.Equal("web:*,worker-* in RUNNING", Match("web:*", "worker-*").InState(StateRunning).String())
+}
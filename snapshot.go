@@ -0,0 +1,128 @@
+package supervisord
+
+import (
+	"context"
+	"time"
+)
+
+// Snapshot is the state of all processes at one point in time, it can be stored as JSON
+// and compared with a later snapshot using Diff.
+type Snapshot struct {
+	Taken         time.Time              `json:"taken"`
+	SupervisorPID int                    `json:"supervisor_pid,omitempty"` // pid of supervisord, 0 when unknown
+	Processes     map[string]ProcessInfo `json:"processes"`                // keyed by "group:name"
+}
+
+type ChangeKind string
+
+const (
+	ChangeAdded      ChangeKind = "added"       // the process appeared, Old is nil
+	ChangeRemoved    ChangeKind = "removed"     // the process disappeared, New is nil
+	ChangeState      ChangeKind = "state"       // the state changed
+	ChangePID        ChangeKind = "pid"         // the process runs under another pid, it restarted between snapshots
+	ChangeExitStatus ChangeKind = "exit_status" // the exit status changed
+	ChangeSpawnErr   ChangeKind = "spawnerr"    // a spawn error appeared or changed
+)
+
+// Change is one difference between two snapshots.
+type Change struct {
+	Kind ChangeKind   `json:"kind"`
+	Name string       `json:"name"`
+	Old  *ProcessInfo `json:"old,omitempty"`
+	New  *ProcessInfo `json:"new,omitempty"`
+}
+
+// NewSnapshot builds a snapshot of infos taken now.
+func NewSnapshot(infos []ProcessInfo) *Snapshot {
+	s := &Snapshot{Taken: time.Now(), Processes: make(map[string]ProcessInfo, len(infos))}
+
+	for _, pi := range infos {
+		s.Processes[pi.FullName()] = pi
+	}
+
+	return s
+}
+
+// Snapshot fetches all process info and the supervisord pid in one round trip.
+func (c *Client) Snapshot(ctx context.Context) (*Snapshot, error) {
+	b := c.NewBatch()
+	infos := b.GetAllProcessInfo()
+	pid := b.GetPID()
+
+	if err := b.ExecContext(ctx); err != nil {
+		return nil, err
+	}
+
+	all, err := infos.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	s := NewSnapshot(all)
+	s.SupervisorPID, _ = pid.Value()
+
+	return s, nil
+}
+
+// Names returns the sorted "group:name" of the processes in the snapshot.
+func (s *Snapshot) Names() []string {
+	return sortedKeys(s.Processes)
+}
+
+// Diff returns the changes from s to next, sorted by process name.
+func (s *Snapshot) Diff(next *Snapshot) []Change {
+	names := make(map[string]bool, len(s.Processes))
+	for name := range s.Processes {
+		names[name] = true
+	}
+
+	for name := range next.Processes {
+		names[name] = true
+	}
+
+	var changes []Change
+
+	for _, name := range sortedKeys(names) {
+		oldPI, hadOld := s.Processes[name]
+		newPI, hasNew := next.Processes[name]
+
+		switch {
+		case !hadOld:
+			changes = append(changes, Change{Kind: ChangeAdded, Name: name, New: &newPI})
+		case !hasNew:
+			changes = append(changes, Change{Kind: ChangeRemoved, Name: name, Old: &oldPI})
+		default:
+			changes = append(changes, diffProcess(name, &oldPI, &newPI)...)
+		}
+	}
+
+	return changes
+}
+
+// diffProcess compares two infos of the same process.
+func diffProcess(name string, oldPI, newPI *ProcessInfo) []Change {
+	var kinds []ChangeKind
+
+	if oldPI.State != newPI.State {
+		kinds = append(kinds, ChangeState)
+	}
+
+	if oldPI.Pid != 0 && newPI.Pid != 0 && oldPI.Pid != newPI.Pid {
+		kinds = append(kinds, ChangePID)
+	}
+
+	if oldPI.ExitStatus != newPI.ExitStatus {
+		kinds = append(kinds, ChangeExitStatus)
+	}
+
+	if newPI.SpawnErr != "" && newPI.SpawnErr != oldPI.SpawnErr {
+		kinds = append(kinds, ChangeSpawnErr)
+	}
+
+	changes := make([]Change, len(kinds))
+	for i, kind := range kinds {
+		changes[i] = Change{Kind: kind, Name: name, Old: oldPI, New: newPI}
+	}
+
+	return changes
+}
@@ -0,0 +1,66 @@
+package supervisord
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SnapshotSuite struct {
+	suite.Suite
+}
+
+func TestSnapshot(t *testing.T) {
+	suite.Run(t, new(SnapshotSuite))
+}
+
+func (s *SnapshotSuite) kinds(changes []Change) []string {
+	var kinds []string
+	for _, ch := range changes {
+		kinds = append(kinds, ch.Name+" "+string(ch.Kind))
+	}
+
+	return kinds
+}
+
+func (s *SnapshotSuite) Test_01_diff() {
+	before := NewSnapshot([]ProcessInfo{
+		{Group: "web", Name: "web_00", State: StateRunning, Pid: 10},
+		{Group: "api", Name: "api", State: StateRunning, Pid: 11},
+		{Group: "cron", Name: "cron", State: StateRunning, Pid: 12},
+		{Group: "old", Name: "old", State: StateStopped},
+	})
+	after := NewSnapshot([]ProcessInfo{
+		{Group: "web", Name: "web_00", State: StateRunning, Pid: 20},
+		{Group: "api", Name: "api", State: StateBackoff, ExitStatus: 1, SpawnErr: "Exited too quickly"},
+		{Group: "cron", Name: "cron", State: StateRunning, Pid: 12},
+		{Group: "new", Name: "new", State: StateStarting, Pid: 30},
+	})
+
+	s.Equal([]string{
+		"api:api state",
+		"api:api exit_status",
+		"api:api spawnerr",
+		"new:new added",
+		"old:old removed",
+		"web:web_00 pid",
+	}, s.kinds(before.Diff(after)))
+
+	s.Nil(after.Diff(after))
+}
+
+func (s *SnapshotSuite) Test_02_json() {
+	snap := NewSnapshot([]ProcessInfo{{Group: "web", Name: "web_00", State: StateRunning, Pid: 10}})
+	snap.SupervisorPID = 1
+
+	raw, err := json.Marshal(snap)
+	s.Nil(err)
+
+	var loaded Snapshot
+	s.Nil(json.Unmarshal(raw, &loaded))
+	s.Equal(snap.Processes, loaded.Processes)
+	s.Equal(1, loaded.SupervisorPID)
+	s.True(snap.Taken.Equal(loaded.Taken))
+	s.Equal([]string{"web:web_00"}, loaded.Names())
+}
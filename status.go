@@ -1,5 +1,7 @@
 package supervisord
 
+import "context"
+
 type (
 	StateCode int
 	StateName string
@@ -23,42 +25,78 @@ const (
 )
 
 func (c *Client) GetAPIVersion() (string, error) {
-	return c.CallAsStr(getAPIVersion)
+	return c.GetAPIVersionContext(context.Background())
+}
+
+func (c *Client) GetAPIVersionContext(ctx context.Context) (string, error) {
+	return c.CallAsStrContext(ctx, getAPIVersion)
 }
 
 func (c *Client) GetSupervisorVersion() (string, error) {
-	return c.CallAsStr(getSupervisorVersion)
+	return c.GetSupervisorVersionContext(context.Background())
+}
+
+func (c *Client) GetSupervisorVersionContext(ctx context.Context) (string, error) {
+	return c.CallAsStrContext(ctx, getSupervisorVersion)
 }
 
 func (c *Client) GetIdentification() (string, error) {
-	return c.CallAsStr(getIdentification)
+	return c.GetIdentificationContext(context.Background())
+}
+
+func (c *Client) GetIdentificationContext(ctx context.Context) (string, error) {
+	return c.CallAsStrContext(ctx, getIdentification)
 }
 
 func (c *Client) GetState() (State, error) {
+	return c.GetStateContext(context.Background())
+}
+
+func (c *Client) GetStateContext(ctx context.Context) (State, error) {
 	var state State
-	err := c.call(getState, nil, &state)
+	err := c.callContext(ctx, getState, nil, &state)
 
 	return state, err
 }
 
 func (c *Client) GetPID() (int, error) {
-	return c.CallAsInt(getPID)
+	return c.GetPIDContext(context.Background())
+}
+
+func (c *Client) GetPIDContext(ctx context.Context) (int, error) {
+	return c.CallAsIntContext(ctx, getPID)
 }
 
 func (c *Client) ReadLog(offset int64, length int) (string, error) {
+	return c.ReadLogContext(context.Background(), offset, length)
+}
+
+func (c *Client) ReadLogContext(ctx context.Context, offset int64, length int) (string, error) {
 	arg := []interface{}{offset, length}
 
-	return c.CallAsStr(readLog, arg) // nolint
+	return c.CallAsStrContext(ctx, readLog, arg) // nolint
 }
 
 func (c *Client) ClearLog() error {
-	return c.CallAsBool(clearLog)
+	return c.ClearLogContext(context.Background())
+}
+
+func (c *Client) ClearLogContext(ctx context.Context) error {
+	return c.CallAsBoolContext(ctx, clearLog)
 }
 
 func (c *Client) Shutdown() error {
-	return c.CallAsBool(shutdown)
+	return c.ShutdownContext(context.Background())
+}
+
+func (c *Client) ShutdownContext(ctx context.Context) error {
+	return c.CallAsBoolContext(ctx, shutdown)
 }
 
 func (c *Client) Restart() error {
-	return c.CallAsBool(restart)
+	return c.RestartContext(context.Background())
+}
+
+func (c *Client) RestartContext(ctx context.Context) error {
+	return c.CallAsBoolContext(ctx, restart)
 }
@@ -1,22 +1,36 @@
 package supervisord
 
+import "context"
+
 type CmdCall struct {
 	MethodName string        `xmlrpc:"methodName"`
 	Params     []interface{} `xmlrpc:"params"`
 }
 
 func (c *Client) ListMethods() ([]string, error) {
-	return c.CallAsStrArray(listMethods)
+	return c.ListMethodsContext(context.Background())
+}
+
+func (c *Client) ListMethodsContext(ctx context.Context) ([]string, error) {
+	return c.CallAsStrArrayContext(ctx, listMethods)
 }
 
 func (c *Client) MethodHelp(cmd CMD) (string, error) {
+	return c.MethodHelpContext(context.Background(), cmd)
+}
+
+func (c *Client) MethodHelpContext(ctx context.Context, cmd CMD) (string, error) {
 	name := c.refineCmd(cmd)
-	return c.CallAsStr(methodHelp, name)
+	return c.CallAsStrContext(ctx, methodHelp, name)
 }
 
 func (c *Client) MethodSignature(cmd CMD) ([]interface{}, error) {
+	return c.MethodSignatureContext(context.Background(), cmd)
+}
+
+func (c *Client) MethodSignatureContext(ctx context.Context, cmd CMD) ([]interface{}, error) {
 	name := c.refineCmd(cmd)
-	return c.CallAsInterfaceArray(methodSignature, name)
+	return c.CallAsInterfaceArrayContext(ctx, methodSignature, name)
 }
 
 // Multicall
@@ -35,5 +49,9 @@ func (c *Client) MethodSignature(cmd CMD) ([]interface{}, error) {
 //		},
 //	}
 func (c *Client) Multicall(calls []CmdCall) ([]interface{}, error) {
-	return c.CallAsInterfaceArray(multicall, calls)
+	return c.MulticallContext(context.Background(), calls)
+}
+
+func (c *Client) MulticallContext(ctx context.Context, calls []CmdCall) ([]interface{}, error) {
+	return c.CallAsInterfaceArrayContext(ctx, multicall, calls)
 }
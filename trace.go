@@ -0,0 +1,151 @@
+package supervisord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Logger is the structured logger debug output is written to, *slog.Logger satisfies it.
+type Logger interface {
+	Debug(msg string, keyvals ...any)
+}
+
+// zerologLogger writes to the global zerolog logger, it is used when no logger is set.
+type zerologLogger struct{}
+
+func (zerologLogger) Debug(msg string, keyvals ...any) {
+	log.Debug().Fields(keyvals).Msg(msg)
+}
+
+// CallTrace describes one finished xmlrpc call, args are redacted.
+type CallTrace struct {
+	Method   string
+	Args     []any
+	Duration time.Duration
+	Err      error
+	Fault    *Fault // set when supervisord answered with a fault
+}
+
+// TraceFunc is called after every call, including calls which failed.
+type TraceFunc func(CallTrace)
+
+// WithLogger sets the logger debug output goes to, the global zerolog logger by default.
+func WithLogger(l Logger) ClientOptions {
+	return func(o *Client) {
+		o.logger = l
+	}
+}
+
+// WithDebug logs every call with its redacted args, duration and error.
+func WithDebug(b bool) ClientOptions {
+	return func(o *Client) {
+		o.debug = b
+	}
+}
+
+// WithTrace calls fn after every call, e.g. to record metrics or spans.
+func WithTrace(fn TraceFunc) ClientOptions {
+	return func(o *Client) {
+		o.trace = fn
+	}
+}
+
+// invokeWithTrace wraps invokeWithRetry with the debug log and the trace hook.
+func (c *Client) invokeWithTrace(ctx context.Context, method string, args any, reply any) error {
+	if !c.debug && c.trace == nil {
+		return asFault(c.invokeWithRetry(ctx, method, args, reply))
+	}
+
+	start := time.Now()
+	err := asFault(c.invokeWithRetry(ctx, method, args, reply))
+
+	ct := CallTrace{
+		Method:   method,
+		Args:     redactArgs(method, args),
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	errors.As(err, &ct.Fault)
+
+	if c.debug {
+		c.logger.Debug("xmlrpc call", "method", ct.Method, "args", ct.Args, "duration", ct.Duration, "error", ct.Err)
+	}
+
+	if c.trace != nil {
+		c.trace(ct)
+	}
+
+	return err
+}
+
+// redactedArgs maps fully qualified methods to the positions of args which must never be logged.
+var redactedArgs = map[string][]int{
+	_prefix + string(sendProcessStdin):    {1},
+	_prefix + string(sendRemoteCommEvent): {1},
+	"twiddler.addProgramToGroup":          {2}, // the program options carry its environment
+}
+
+// redactArgs returns a copy of args safe to log: stdin payloads are replaced by their size
+// and credentials in urls are masked.
+func redactArgs(method string, args any) []any {
+	var list []any
+
+	switch v := args.(type) {
+	case nil:
+		return nil
+	case []any:
+		list = v
+	default:
+		list = []any{v}
+	}
+
+	out := make([]any, len(list))
+
+	for i, arg := range list {
+		out[i] = redactValue(arg)
+	}
+
+	for _, i := range redactedArgs[method] {
+		if i < len(out) {
+			out[i] = redactedPayload(list[i])
+		}
+	}
+
+	return out
+}
+
+func redactValue(arg any) any {
+	switch v := arg.(type) {
+	case string:
+		if u, err := url.Parse(v); err == nil && u.User != nil {
+			return u.Redacted()
+		}
+
+		return v
+	case []CmdCall:
+		calls := make([]CmdCall, len(v))
+		for i, call := range v {
+			calls[i] = CmdCall{MethodName: call.MethodName, Params: redactArgs(call.MethodName, call.Params)}
+		}
+
+		return calls
+	default:
+		return v
+	}
+}
+
+func redactedPayload(arg any) string {
+	switch v := arg.(type) {
+	case string:
+		return fmt.Sprintf("[redacted %d bytes]", len(v))
+	case map[string]string:
+		return fmt.Sprintf("[redacted %d options]", len(v))
+	}
+
+	return "[redacted]"
+}
@@ -0,0 +1,218 @@
+package supervisord
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	_schemeUnix = "unix://"
+
+	// supervisorctl talks to unix sockets with this placeholder url, the host part is never resolved.
+	_unixRPCURL = "http://127.0.0.1/RPC2"
+)
+
+var ErrTransportOption = errors.New("invalid transport option")
+
+// transportConfig holds the options used to build the http stack under the basic auth transport.
+type transportConfig struct {
+	client    *http.Client
+	rt        http.RoundTripper
+	tlsConfig *tls.Config
+	certs     []tls.Certificate
+	rootCAs   *x509.CertPool
+	proxy     func(*http.Request) (*url.URL, error)
+	timeout   time.Duration
+}
+
+// WithHTTPClient sends requests through hc, its transport is wrapped by the basic auth transport
+// and its Timeout, Jar and CheckRedirect are kept.
+func WithHTTPClient(hc *http.Client) ClientOptions {
+	return func(o *Client) {
+		o.transport.client = hc
+	}
+}
+
+// WithTransport replaces http.DefaultTransport as the base round tripper.
+func WithTransport(rt http.RoundTripper) ClientOptions {
+	return func(o *Client) {
+		o.transport.rt = rt
+	}
+}
+
+// WithTLSConfig sets the tls config used for https urls, certificates and CAs set
+// by WithClientCertificate and WithRootCAs are added to a clone of cfg.
+func WithTLSConfig(cfg *tls.Config) ClientOptions {
+	return func(o *Client) {
+		o.transport.tlsConfig = cfg
+	}
+}
+
+// WithClientCertificate loads a PEM encoded certificate and key pair presented to the server.
+func WithClientCertificate(certFile, keyFile string) ClientOptions {
+	return func(o *Client) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			o.optErr = errors.Join(o.optErr, fmt.Errorf("cannot load client certificate: %w", err))
+			return
+		}
+
+		o.transport.certs = append(o.transport.certs, cert)
+	}
+}
+
+// WithRootCAs verifies the server certificate against pool instead of the system roots.
+func WithRootCAs(pool *x509.CertPool) ClientOptions {
+	return func(o *Client) {
+		o.transport.rootCAs = pool
+	}
+}
+
+// WithCAFile verifies the server certificate against the PEM encoded CAs in file.
+func WithCAFile(file string) ClientOptions {
+	return func(o *Client) {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			o.optErr = errors.Join(o.optErr, fmt.Errorf("cannot read ca file: %w", err))
+			return
+		}
+
+		if o.transport.rootCAs == nil {
+			o.transport.rootCAs = x509.NewCertPool()
+		}
+
+		if !o.transport.rootCAs.AppendCertsFromPEM(raw) {
+			o.optErr = errors.Join(o.optErr, fmt.Errorf("%w: no certificate found in %s", ErrTransportOption, file))
+		}
+	}
+}
+
+// WithProxy sets the proxy func of the transport, e.g. http.ProxyURL(u) or http.ProxyFromEnvironment.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) ClientOptions {
+	return func(o *Client) {
+		o.transport.proxy = proxy
+	}
+}
+
+// WithTimeout limits the time of each request, including reading the response body.
+func WithTimeout(d time.Duration) ClientOptions {
+	return func(o *Client) {
+		o.transport.timeout = d
+	}
+}
+
+func (cfg transportConfig) needsHTTPTransport() bool {
+	return cfg.tlsConfig != nil || len(cfg.certs) != 0 || cfg.rootCAs != nil || cfg.proxy != nil
+}
+
+// roundTripper builds the round tripper the basic auth transport wraps.
+func (cfg transportConfig) roundTripper(socket string) (http.RoundTripper, error) {
+	rt := cfg.rt
+	if rt == nil && cfg.client != nil {
+		rt = cfg.client.Transport
+	}
+
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	if socket == "" && !cfg.needsHTTPTransport() {
+		return rt, nil
+	}
+
+	base, ok := rt.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("%w: unix socket, tls and proxy options need an *http.Transport, got %T", ErrTransportOption, rt)
+	}
+
+	tr := base.Clone()
+
+	if socket != "" {
+		tr.Proxy = nil
+		tr.DialContext = unixDialer(socket)
+	}
+
+	if cfg.proxy != nil {
+		tr.Proxy = cfg.proxy
+	}
+
+	if cfg.tlsConfig != nil || len(cfg.certs) != 0 || cfg.rootCAs != nil {
+		tlsConfig := cfg.tlsConfig
+		if tlsConfig == nil {
+			tlsConfig = tr.TLSClientConfig
+		}
+
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cfg.certs...)
+
+		if cfg.rootCAs != nil {
+			tlsConfig.RootCAs = cfg.rootCAs
+		}
+
+		tr.TLSClientConfig = tlsConfig
+	}
+
+	return tr, nil
+}
+
+// httpClient returns the client requests are sent with, rt is the fully wrapped round tripper.
+func (cfg transportConfig) httpClient(rt http.RoundTripper) (*http.Client, error) {
+	hc := &http.Client{}
+	if cfg.client != nil {
+		copied := *cfg.client
+		hc = &copied
+	}
+
+	hc.Transport = rt
+
+	if cfg.timeout != 0 {
+		hc.Timeout = cfg.timeout
+	}
+
+	if hc.Jar == nil {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		hc.Jar = jar
+	}
+
+	return hc, nil
+}
+
+// resolveURL returns the url the xmlrpc requests are sent to and the unix socket
+// to dial, socket is empty when the server is reached over tcp.
+func resolveURL(rawURL, socket string) (string, string) {
+	if socket != "" {
+		return _unixRPCURL, socket
+	}
+
+	if strings.HasPrefix(rawURL, _schemeUnix) {
+		return _unixRPCURL, strings.TrimPrefix(rawURL, _schemeUnix)
+	}
+
+	return rawURL, ""
+}
+
+// unixDialer dials the unix socket for every request regardless of the host in the request url.
+func unixDialer(socket string) func(ctx context.Context, _, _ string) (net.Conn, error) {
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", socket)
+	}
+}
@@ -0,0 +1,237 @@
+package supervisord
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+type TransportSuite struct {
+	suite.Suite
+	dir string
+}
+
+func TestTransport(t *testing.T) {
+	suite.Run(t, new(TransportSuite))
+}
+
+func (s *TransportSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+}
+
+func (s *TransportSuite) serveTrue(w http.ResponseWriter, r *http.Request) {
+	_, _ = io.ReadAll(r.Body)
+	_, _ = w.Write([]byte(boolTrueResp))
+}
+
+func (s *TransportSuite) writePEM(name, typ string, der []byte) string {
+	p := filepath.Join(s.dir, name)
+	s.Nil(os.WriteFile(p, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600))
+
+	return p
+}
+
+// clientCertificate writes a self-signed client certificate and its key, returning their paths.
+func (s *TransportSuite) clientCertificate() (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Nil(err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "supervisorctl"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	s.Nil(err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	s.Nil(err)
+
+	return s.writePEM("client.pem", "CERTIFICATE", der), s.writePEM("client.key", "EC PRIVATE KEY", keyDER)
+}
+
+func (s *TransportSuite) Test_01_rootCAs() {
+	srv := httptest.NewTLSServer(http.HandlerFunc(s.serveTrue))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL+"/RPC2", WithCapabilityCheck(false))
+	s.Nil(err)
+
+	var certErr *tls.CertificateVerificationError
+	s.ErrorAs(c.ClearLog(), &certErr)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	c, err = NewClient(srv.URL+"/RPC2", WithRootCAs(pool), WithCapabilityCheck(false))
+	s.Nil(err)
+	s.Nil(c.ClearLog())
+}
+
+func (s *TransportSuite) Test_02_caFile() {
+	srv := httptest.NewTLSServer(http.HandlerFunc(s.serveTrue))
+	defer srv.Close()
+
+	ca := s.writePEM("ca.pem", "CERTIFICATE", srv.Certificate().Raw)
+
+	c, err := NewClient(srv.URL+"/RPC2", WithCAFile(ca), WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}), WithCapabilityCheck(false))
+	s.Nil(err)
+	s.Nil(c.ClearLog())
+
+	bad := filepath.Join(s.dir, "bad.pem")
+	s.Nil(os.WriteFile(bad, []byte("not a certificate"), 0o600))
+
+	_, err = NewClient(srv.URL+"/RPC2", WithCAFile(bad))
+	s.ErrorIs(err, ErrTransportOption)
+
+	_, err = NewClient(srv.URL+"/RPC2", WithCAFile(filepath.Join(s.dir, "missing.pem")))
+	s.ErrorIs(err, os.ErrNotExist)
+}
+
+func (s *TransportSuite) Test_03_clientCertificate() {
+	var peers int
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		peers = len(r.TLS.PeerCertificates)
+		s.serveTrue(w, r)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	srv.StartTLS()
+
+	defer srv.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	c, err := NewClient(srv.URL+"/RPC2", WithRootCAs(pool), WithCapabilityCheck(false))
+	s.Nil(err)
+	s.NotNil(c.ClearLog())
+
+	cert, key := s.clientCertificate()
+
+	c, err = NewClient(srv.URL+"/RPC2", WithRootCAs(pool), WithClientCertificate(cert, key), WithCapabilityCheck(false))
+	s.Nil(err)
+	s.Nil(c.ClearLog())
+	s.Equal(1, peers)
+
+	_, err = NewClient(srv.URL+"/RPC2", WithClientCertificate(key, cert))
+	s.NotNil(err)
+}
+
+func (s *TransportSuite) Test_04_proxy() {
+	var target string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = r.URL.String()
+		s.serveTrue(w, r)
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	s.Nil(err)
+
+	var proxied []string
+
+	c, err := NewClient("http://supervisor.invalid:9001/RPC2", WithProxy(func(r *http.Request) (*url.URL, error) {
+		proxied = append(proxied, r.URL.Host)
+		return proxyURL, nil
+	}), WithCapabilityCheck(false))
+	s.Nil(err)
+	s.Nil(c.ClearLog())
+	s.Equal([]string{"supervisor.invalid:9001"}, proxied)
+	s.Equal("http://supervisor.invalid:9001/RPC2", target)
+}
+
+func (s *TransportSuite) Test_05_customTransport() {
+	var methods []string
+
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(r.Body)
+		methods = append(methods, methodNameRx.FindStringSubmatch(string(body))[1])
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(boolTrueResp)),
+			Header:     http.Header{},
+			Request:    r,
+		}, nil
+	})
+
+	c, err := NewClient("http://localhost:9001/RPC2", WithTransport(rt), WithCapabilityCheck(false))
+	s.Nil(err)
+	s.Nil(c.ClearLog())
+
+	hc := &http.Client{Transport: rt}
+
+	c, err = NewClient("http://localhost:9001/RPC2", WithHTTPClient(hc), WithTimeout(time.Second), WithCapabilityCheck(false))
+	s.Nil(err)
+	s.Nil(c.ClearLog())
+	s.Equal([]string{"supervisor.clearLog", "supervisor.clearLog"}, methods)
+	s.Equal(time.Second, c.httpClient.Timeout)
+	s.NotNil(c.httpClient.Jar)
+
+	for _, opt := range []ClientOptions{
+		WithProxy(http.ProxyFromEnvironment),
+		WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}),
+		WithRootCAs(x509.NewCertPool()),
+		WithUnixSocket("/tmp/supervisor.sock"),
+	} {
+		_, err = NewClient("http://localhost:9001/RPC2", WithTransport(rt), opt)
+		s.ErrorIs(err, ErrTransportOption)
+
+		_, err = NewClient("http://localhost:9001/RPC2", WithHTTPClient(hc), opt)
+		s.ErrorIs(err, ErrTransportOption)
+	}
+}
+
+func (s *TransportSuite) Test_06_timeout() {
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c, err := NewClient(srv.URL+"/RPC2", WithTimeout(50*time.Millisecond), WithCapabilityCheck(false))
+	s.Nil(err)
+
+	begin := time.Now()
+	err = c.ClearLog()
+
+	var netErr net.Error
+	s.True(errors.As(err, &netErr) && netErr.Timeout(), "%v", err)
+	s.Less(time.Since(begin), time.Second)
+}
@@ -0,0 +1,198 @@
+package supervisord
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Twiddler is a client for the supervisor_twiddler plugin, which adds and removes
+// programs at runtime without editing the config and calling ReloadConfig.
+//
+// https://github.com/mnaberez/supervisor_twiddler
+//
+// Example:
+//
+//	tw := c.Twiddler()
+//
+//	err := tw.AddProgramToGroup(ctx, "workers", "worker_07", ProgramOptions{
+//		Command:   "/usr/local/bin/worker --id 7",
+//		Autostart: Bool(true),
+//		Startsecs: Int(0),
+//	})
+type Twiddler struct {
+	ns *Namespace
+}
+
+// ProgramOptions are the [program:x] options of a program added through twiddler,
+// nil and empty fields are left to supervisor defaults.
+type ProgramOptions struct {
+	Command               string
+	ProcessName           string
+	Numprocs              *int
+	Directory             string
+	Umask                 string
+	Priority              *int
+	Autostart             *bool
+	Autorestart           string // "true", "false" or "unexpected"
+	Startsecs             *int
+	Startretries          *int
+	Exitcodes             []int
+	Stopsignal            string // signal name, e.g. "TERM"
+	Stopwaitsecs          *int
+	Stopasgroup           *bool
+	Killasgroup           *bool
+	User                  string
+	RedirectStderr        *bool
+	StdoutLogfile         string
+	StdoutLogfileMaxbytes string // e.g. "50MB"
+	StdoutLogfileBackups  *int
+	StdoutCaptureMaxbytes string
+	StdoutEventsEnabled   *bool
+	StdoutSyslog          *bool
+	StderrLogfile         string
+	StderrLogfileMaxbytes string
+	StderrLogfileBackups  *int
+	StderrCaptureMaxbytes string
+	StderrEventsEnabled   *bool
+	StderrSyslog          *bool
+	Environment           map[string]string
+	Serverurl             string
+
+	// Extra holds options not covered above, they override the fields.
+	Extra map[string]string
+}
+
+// Bool returns a pointer to b, for the optional fields of ProgramOptions.
+func Bool(b bool) *bool {
+	return &b
+}
+
+// Int returns a pointer to n, for the optional fields of ProgramOptions.
+func Int(n int) *int {
+	return &n
+}
+
+// Twiddler returns the twiddler plugin client, registering the twiddler namespace.
+func (c *Client) Twiddler() *Twiddler {
+	return &Twiddler{ns: c.Namespace("twiddler")}
+}
+
+func (t *Twiddler) GetAPIVersion(ctx context.Context) (string, error) {
+	var reply string
+	err := t.ns.Call(ctx, "getAPIVersion", &reply)
+
+	return reply, err
+}
+
+func (t *Twiddler) GetGroupNames(ctx context.Context) ([]string, error) {
+	var reply []string
+	err := t.ns.Call(ctx, "getGroupNames", &reply)
+
+	return reply, err
+}
+
+// AddGroup creates an empty process group, programs are then added with AddProgramToGroup.
+func (t *Twiddler) AddGroup(ctx context.Context, name string, priority int) error {
+	return t.ns.CallAsBool(ctx, "addGroup", name, priority)
+}
+
+// AddProgramToGroup adds program to the existing group, it is started when Autostart is not false.
+func (t *Twiddler) AddProgramToGroup(ctx context.Context, group, program string, opts ProgramOptions) error {
+	return t.ns.CallAsBool(ctx, "addProgramToGroup", group, program, opts.toMap())
+}
+
+// RemoveProcessFromGroup removes a stopped process from group.
+func (t *Twiddler) RemoveProcessFromGroup(ctx context.Context, group, process string) error {
+	return t.ns.CallAsBool(ctx, "removeProcessFromGroup", group, process)
+}
+
+// Log writes message to the supervisord main log at level, such as "INFO" or "WARN".
+func (t *Twiddler) Log(ctx context.Context, message, level string) error {
+	return t.ns.CallAsBool(ctx, "log", message, level)
+}
+
+// toMap converts the options to the string values twiddler parses like the config file.
+func (o ProgramOptions) toMap() map[string]string {
+	m := make(map[string]string)
+
+	setStr := func(k, v string) {
+		if v != "" {
+			m[k] = v
+		}
+	}
+
+	setInt := func(k string, v *int) {
+		if v != nil {
+			m[k] = strconv.Itoa(*v)
+		}
+	}
+
+	setBool := func(k string, v *bool) {
+		if v != nil {
+			m[k] = strconv.FormatBool(*v)
+		}
+	}
+
+	setStr("command", o.Command)
+	setStr("process_name", o.ProcessName)
+	setInt("numprocs", o.Numprocs)
+	setStr("directory", o.Directory)
+	setStr("umask", o.Umask)
+	setInt("priority", o.Priority)
+	setBool("autostart", o.Autostart)
+	setStr("autorestart", o.Autorestart)
+	setInt("startsecs", o.Startsecs)
+	setInt("startretries", o.Startretries)
+	setStr("stopsignal", o.Stopsignal)
+	setInt("stopwaitsecs", o.Stopwaitsecs)
+	setBool("stopasgroup", o.Stopasgroup)
+	setBool("killasgroup", o.Killasgroup)
+	setStr("user", o.User)
+	setBool("redirect_stderr", o.RedirectStderr)
+	setStr("stdout_logfile", o.StdoutLogfile)
+	setStr("stdout_logfile_maxbytes", o.StdoutLogfileMaxbytes)
+	setInt("stdout_logfile_backups", o.StdoutLogfileBackups)
+	setStr("stdout_capture_maxbytes", o.StdoutCaptureMaxbytes)
+	setBool("stdout_events_enabled", o.StdoutEventsEnabled)
+	setBool("stdout_syslog", o.StdoutSyslog)
+	setStr("stderr_logfile", o.StderrLogfile)
+	setStr("stderr_logfile_maxbytes", o.StderrLogfileMaxbytes)
+	setInt("stderr_logfile_backups", o.StderrLogfileBackups)
+	setStr("stderr_capture_maxbytes", o.StderrCaptureMaxbytes)
+	setBool("stderr_events_enabled", o.StderrEventsEnabled)
+	setBool("stderr_syslog", o.StderrSyslog)
+	setStr("serverurl", o.Serverurl)
+
+	if len(o.Exitcodes) != 0 {
+		codes := make([]string, len(o.Exitcodes))
+		for i, code := range o.Exitcodes {
+			codes[i] = strconv.Itoa(code)
+		}
+
+		m["exitcodes"] = strings.Join(codes, ",")
+	}
+
+	if len(o.Environment) != 0 {
+		keys := make([]string, 0, len(o.Environment))
+		for k := range o.Environment {
+			keys = append(keys, k)
+		}
+
+		slices.Sort(keys)
+
+		pairs := make([]string, len(keys))
+		for i, k := range keys {
+			pairs[i] = k + "=" + strconv.Quote(o.Environment[k])
+		}
+
+		m["environment"] = strings.Join(pairs, ",")
+	}
+
+	for k, v := range o.Extra {
+		m[k] = v
+	}
+
+	return m
+}
@@ -0,0 +1,46 @@
+package supervisord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TwiddlerSuite struct {
+	suite.Suite
+}
+
+func TestTwiddler(t *testing.T) {
+	suite.Run(t, new(TwiddlerSuite))
+}
+
+func (s *TwiddlerSuite) Test_01_toMap() {
+	opts := ProgramOptions{
+		Command:   "/usr/local/bin/worker --id 7",
+		Autostart: Bool(false),
+		Startsecs: Int(0),
+		Exitcodes: []int{0, 2},
+		Environment: map[string]string{
+			"TOKEN": `se"cret`,
+			"HOME":  "/home/worker",
+		},
+		Extra: map[string]string{"startsecs": "5"},
+	}
+
+	s.Equal(map[string]string{
+		"command":     "/usr/local/bin/worker --id 7",
+		"autostart":   "false",
+		"startsecs":   "5",
+		"exitcodes":   "0,2",
+		"environment": `HOME="/home/worker",TOKEN="se\"cret"`,
+	}, opts.toMap())
+
+	s.Empty(ProgramOptions{}.toMap())
+}
+
+func (s *TwiddlerSuite) Test_02_redact() {
+	opts := ProgramOptions{Command: "worker", Environment: map[string]string{"TOKEN": "secret"}}
+
+	args := redactArgs("twiddler.addProgramToGroup", []any{"workers", "worker_07", opts.toMap()})
+	s.Equal([]any{"workers", "worker_07", "[redacted 2 options]"}, args)
+}
@@ -0,0 +1,152 @@
+package supervisord
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const _maxPollInterval = 5 * time.Second
+
+// failedStates are the states a waited process is not expected to land in, unless waited for.
+var failedStates = []ProcessState{StateBackoff, StateFatal}
+
+// WaitForState polls the process with backoff until it is in one of states and returns its info.
+// It fails early with a *StateError when the process reaches BACKOFF or FATAL and those are not
+// among states, and returns ctx.Err() when ctx is done first.
+func (c *Client) WaitForState(ctx context.Context, name string, states ...ProcessState) (*ProcessInfo, error) {
+	return c.waitForState(ctx, name, c.pollInterval(), states...)
+}
+
+// waitForState is WaitForState polling first after interval instead of the client poll interval.
+func (c *Client) waitForState(ctx context.Context, name string, interval time.Duration, states ...ProcessState) (*ProcessInfo, error) {
+	name = FullName(name)
+
+	for {
+		pi, err := c.GetProcessInfoContext(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.Contains(states, pi.State) {
+			return pi, nil
+		}
+
+		if slices.Contains(failedStates, pi.State) {
+			return pi, &StateError{Name: name, Info: *pi}
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return pi, err
+		}
+
+		interval = min(interval*2, _maxPollInterval)
+	}
+}
+
+// WaitForAll polls until every process selected by sel is in state and returns their info.
+// The processes are selected once, when the call starts, one of them disappearing fails
+// the wait with BAD_NAME.
+func (c *Client) WaitForAll(ctx context.Context, sel *Selector, state ProcessState) ([]ProcessInfo, error) {
+	selected, err := c.Select(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	waiting := make(map[string]bool, len(selected))
+	for _, pi := range selected {
+		waiting[pi.FullName()] = true
+	}
+
+	interval := c.pollInterval()
+
+	for {
+		all, err := c.GetAllProcessInfoContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			infos []ProcessInfo
+			done  = true
+		)
+
+		for _, pi := range all {
+			if !waiting[pi.FullName()] {
+				continue
+			}
+
+			infos = append(infos, pi)
+
+			if pi.State == state {
+				continue
+			}
+
+			if state != StateBackoff && state != StateFatal && slices.Contains(failedStates, pi.State) {
+				return infos, &StateError{Name: pi.FullName(), Info: pi}
+			}
+
+			done = false
+		}
+
+		if len(infos) != len(waiting) {
+			return infos, missingProcess(waiting, infos)
+		}
+
+		if done {
+			return infos, nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return infos, err
+		}
+
+		interval = min(interval*2, _maxPollInterval)
+	}
+}
+
+// missingProcess returns a BAD_NAME fault for the first waited process absent from infos.
+func missingProcess(waiting map[string]bool, infos []ProcessInfo) error {
+	present := make(map[string]bool, len(infos))
+	for _, pi := range infos {
+		present[pi.FullName()] = true
+	}
+
+	for _, name := range sortedKeys(waiting) {
+		if !present[name] {
+			return NewFault(int(FaultBadName), fmt.Sprintf("%s: %s", FaultBadName, name))
+		}
+	}
+
+	return nil
+}
+
+// WithPollInterval sets the first interval WaitForState and WaitForAll poll at, it doubles
+// after each poll up to 5s. 100ms by default.
+func WithPollInterval(d time.Duration) ClientOptions {
+	return func(o *Client) {
+		o.pollEvery = d
+	}
+}
+
+func (c *Client) pollInterval() time.Duration {
+	if c.pollEvery > 0 {
+		return c.pollEvery
+	}
+
+	return 100 * time.Millisecond
+}
+
+// sleepContext sleeps for d, returning ctx.Err() when ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
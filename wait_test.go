@@ -0,0 +1,93 @@
+package supervisord
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WaitSuite struct {
+	suite.Suite
+	fake *fakeSupervisor
+	c    *Client
+}
+
+func TestWait(t *testing.T) {
+	suite.Run(t, new(WaitSuite))
+}
+
+func (s *WaitSuite) SetupTest() {
+	web := newFakeProcess("web", "web_00", StateStopped)
+	web.startDelay = 50 * time.Millisecond
+
+	broken := newFakeProcess("web", "web_01", StateStopped)
+	broken.failStart = true
+
+	s.fake = newFakeSupervisor(s.T(), web, broken, newFakeProcess("cron", "cron", StateStopped))
+	s.c = s.fake.client()
+}
+
+func (s *WaitSuite) Test_01_state() {
+	s.Nil(s.c.StartProcess("web:web_00", false))
+
+	pi, err := s.c.WaitForState(context.Background(), "web:web_00", StateRunning)
+	s.Nil(err)
+	s.Equal(StateRunning, pi.State)
+	s.Greater(len(s.fake.called("supervisor.getProcessInfo")), 1)
+}
+
+func (s *WaitSuite) Test_02_failedState() {
+	s.Nil(s.c.StartProcess("web:web_01", false))
+
+	pi, err := s.c.WaitForState(context.Background(), "web:web_01", StateRunning)
+	s.ErrorIs(err, ErrUnexpectedState)
+	s.Equal(StateFatal, pi.State)
+
+	pi, err = s.c.WaitForState(context.Background(), "web:web_01", StateFatal)
+	s.Nil(err)
+	s.Equal(StateFatal, pi.State)
+}
+
+func (s *WaitSuite) Test_03_timeout() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	pi, err := s.c.WaitForState(ctx, "cron", StateRunning)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.Equal(StateStopped, pi.State)
+}
+
+func (s *WaitSuite) Test_04_all() {
+	s.Nil(s.c.StartProcess("web:web_00", false))
+	s.Nil(s.c.StartProcess("web:web_01", false))
+
+	infos, err := s.c.WaitForAll(context.Background(), InGroup("web"), StateRunning)
+	s.ErrorIs(err, ErrUnexpectedState)
+	s.Len(infos, 2)
+
+	infos, err = s.c.WaitForAll(context.Background(), Names("web:web_00"), StateRunning)
+	s.Nil(err)
+	s.Len(infos, 1)
+}
+
+func (s *WaitSuite) Test_05_allDisappeared() {
+	s.Nil(s.c.StartProcess("web:web_00", false))
+
+	time.AfterFunc(20*time.Millisecond, func() {
+		s.fake.set(func() {
+			s.fake.procs = slices.DeleteFunc(s.fake.procs, func(p *fakeProcess) bool {
+				return p.info.Name == "cron"
+			})
+		})
+	})
+
+	_, err := s.c.WaitForAll(context.Background(), Names("web:web_00", "cron"), StateRunning)
+	s.ErrorIs(err, ErrBadName)
+
+	var f *Fault
+	s.ErrorAs(err, &f)
+	s.Equal("cron:cron", f.Name)
+}
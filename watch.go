@@ -0,0 +1,128 @@
+package supervisord
+
+import (
+	"context"
+	"time"
+)
+
+const _defaultWatchInterval = time.Second
+
+const (
+	ChangeSupervisorRestarted ChangeKind = "supervisor_restarted" // the pid of supervisord changed, Name is empty
+	ChangeDisconnected        ChangeKind = "disconnected"         // polling started failing, Err tells why
+	ChangeReconnected         ChangeKind = "reconnected"          // polling works again, changes missed meanwhile follow
+)
+
+// StateChange is an event emitted by Watch.
+type StateChange struct {
+	Change
+	Time time.Time // time of the poll which saw the change, shared by the changes of one poll
+	Err  error     // set for ChangeDisconnected
+}
+
+// Watch polls GetAllProcessInfo and the supervisord pid every interval, and emits the changes
+// between successive polls for the processes selected by sel, nil selects all of them.
+// The interval is 1s when it is not positive.
+// A change is emitted when the process matches sel before or after it, so a selector with
+// InState(StateFatal) reports processes entering and leaving FATAL.
+//
+// Failed polls emit one ChangeDisconnected, the next successful poll emits ChangeReconnected
+// followed by the changes against the last good poll. The channel is closed when ctx is done.
+func (c *Client) Watch(ctx context.Context, interval time.Duration, sel *Selector) <-chan StateChange {
+	if sel == nil {
+		sel = All()
+	}
+
+	if interval <= 0 {
+		interval = _defaultWatchInterval
+	}
+
+	ch := make(chan StateChange, 16)
+
+	go c.watch(ctx, interval, sel, ch)
+
+	return ch
+}
+
+func (c *Client) watch(ctx context.Context, interval time.Duration, sel *Selector, ch chan<- StateChange) {
+	defer close(ch)
+
+	var (
+		prev    *Snapshot
+		failing bool
+	)
+
+	emit := func(ev StateChange) bool {
+		if ev.Time.IsZero() {
+			ev.Time = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case ch <- ev:
+			return true
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		snap, err := c.Snapshot(ctx)
+
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			if !failing && !emit(StateChange{Change: Change{Kind: ChangeDisconnected}, Err: err}) {
+				return
+			}
+
+			failing = true
+		default:
+			if failing && !emit(StateChange{Change: Change{Kind: ChangeReconnected}}) {
+				return
+			}
+
+			failing = false
+
+			if prev != nil && !c.emitChanges(prev, snap, sel, emit) {
+				return
+			}
+
+			prev = snap
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// emitChanges emits the changes from prev to snap, returning false when ctx is done.
+func (c *Client) emitChanges(prev, snap *Snapshot, sel *Selector, emit func(StateChange) bool) bool {
+	if prev.SupervisorPID != 0 && snap.SupervisorPID != 0 && prev.SupervisorPID != snap.SupervisorPID {
+		if !emit(StateChange{Change: Change{Kind: ChangeSupervisorRestarted}, Time: snap.Taken}) {
+			return false
+		}
+	}
+
+	for _, change := range prev.Diff(snap) {
+		if !selectsChange(sel, change) {
+			continue
+		}
+
+		if !emit(StateChange{Change: change, Time: snap.Taken}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func selectsChange(sel *Selector, change Change) bool {
+	return (change.Old != nil && sel.Matches(*change.Old)) || (change.New != nil && sel.Matches(*change.New))
+}
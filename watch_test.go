@@ -0,0 +1,134 @@
+package supervisord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WatchSuite struct {
+	suite.Suite
+	fake   *fakeSupervisor
+	c      *Client
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func TestWatch(t *testing.T) {
+	suite.Run(t, new(WatchSuite))
+}
+
+func (s *WatchSuite) SetupTest() {
+	s.fake = newFakeSupervisor(s.T(),
+		newFakeProcess("web", "web_00", StateRunning),
+		newFakeProcess("cron", "cron", StateRunning),
+	)
+	s.c = s.fake.client()
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+}
+
+func (s *WatchSuite) TearDownTest() {
+	s.cancel()
+}
+
+// next returns the next event of kind, skipping the others.
+func (s *WatchSuite) next(ch <-chan StateChange, kind ChangeKind) StateChange {
+	for ev := range ch {
+		if ev.Kind == kind {
+			return ev
+		}
+	}
+
+	s.FailNow("watch closed before " + string(kind))
+
+	return StateChange{}
+}
+
+// polled waits until the watcher polled at least n more times.
+func (s *WatchSuite) polled(n int) {
+	start := len(s.fake.called("system.multicall"))
+
+	for len(s.fake.called("system.multicall")) < start+n {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (s *WatchSuite) Test_01_changes() {
+	ch := s.c.Watch(s.ctx, 10*time.Millisecond, Names("cron"))
+	s.polled(1)
+
+	s.fake.set(func() {
+		s.fake.exit(s.fake.find("web:web_00"), StateExited, 1)
+		s.fake.exit(s.fake.find("cron"), StateExited, 2)
+	})
+
+	ev := s.next(ch, ChangeState)
+	s.Equal("cron:cron", ev.Name)
+	s.Equal(StateRunning, ev.Old.State)
+	s.Equal(StateExited, ev.New.State)
+	s.False(ev.Time.IsZero())
+
+	ev = s.next(ch, ChangeExitStatus)
+	s.Equal("cron:cron", ev.Name)
+	s.Equal(2, ev.New.ExitStatus)
+
+	s.cancel()
+
+	for range ch {
+	}
+}
+
+func (s *WatchSuite) Test_02_reconnect() {
+	ch := s.c.Watch(s.ctx, 10*time.Millisecond, nil)
+	s.polled(1)
+
+	s.fake.set(func() { s.fake.down = true })
+
+	ev := s.next(ch, ChangeDisconnected)
+
+	var statusErr *HTTPStatusError
+	s.ErrorAs(ev.Err, &statusErr)
+
+	// missed while disconnected, reported against the last good poll
+	s.fake.set(func() {
+		s.fake.exit(s.fake.find("web:web_00"), StateStopped, 0)
+		s.fake.down = false
+	})
+
+	ev = <-ch
+	s.Equal(ChangeReconnected, ev.Kind)
+
+	ev = s.next(ch, ChangeState)
+	s.Equal("web:web_00", ev.Name)
+	s.Equal(StateStopped, ev.New.State)
+}
+
+func (s *WatchSuite) Test_03_supervisorRestarted() {
+	ch := s.c.Watch(s.ctx, 10*time.Millisecond, nil)
+	s.polled(1)
+
+	s.fake.set(func() {
+		s.fake.pid = 2
+		s.fake.nextPID++
+		s.fake.find("cron").info.Pid = s.fake.nextPID
+	})
+
+	ev := s.next(ch, ChangeSupervisorRestarted)
+	s.Equal("", ev.Name)
+
+	// the changes of one poll share its time
+	pid := s.next(ch, ChangePID)
+	s.Equal("cron:cron", pid.Name)
+	s.Equal(ev.Time, pid.Time)
+}
+
+func (s *WatchSuite) Test_04_defaultInterval() {
+	ch := s.c.Watch(s.ctx, 0, nil)
+	s.polled(1)
+	s.cancel()
+
+	for range ch {
+	}
+}